@@ -329,8 +329,9 @@ func (priv *PrivateKey) ToBytes() (b []byte) {
 
 // FromBytes converts a 32-byte byte slice to a Bitcoin private key and derives the corresponding Bitcoin public key.
 func (priv *PrivateKey) FromBytes(b []byte) (err error) {
-	if len(b) != 32 {
-		return fmt.Errorf("Invalid private key bytes length %d, expected 32.", len(b))
+	err = ValidatePrivateKey(b)
+	if err != nil {
+		return err
 	}
 
 	priv.D = new(big.Int).SetBytes(b)
@@ -341,6 +342,22 @@ func (priv *PrivateKey) FromBytes(b []byte) (err error) {
 	return nil
 }
 
+// ValidatePrivateKey checks that b is a 32 byte private key in the range [1, N-1] of the curve.
+// a key out of the range produces signatures nobody can verify
+func ValidatePrivateKey(b []byte) error {
+	if len(b) != 32 {
+		return fmt.Errorf("Invalid private key bytes length %d, expected 32.", len(b))
+	}
+	d := new(big.Int).SetBytes(b)
+	if d.Sign() == 0 {
+		return fmt.Errorf("Invalid private key, zero is not a valid key.")
+	}
+	if d.Cmp(secp256r1.N) >= 0 {
+		return fmt.Errorf("Invalid private key, must be less than the curve order.")
+	}
+	return nil
+}
+
 // ToWIF converts a Bitcoin private key to a Wallet Import Format string.
 func (priv *PrivateKey) ToWIF() (wif string) {
 	/* See https://en.bitcoin.it/wiki/Wallet_import_format */
@@ -629,6 +646,10 @@ func Sign(data []byte, key string) ([]byte, error) {
 	}
 
 	params := privateKey.Curve.Params()
+	//s and N-s are both valid. always use the low one so the signature passes IsCanonicalSignature too
+	if s.Cmp(new(big.Int).Rsh(secp256r1.N, 1)) > 0 {
+		s = new(big.Int).Sub(secp256r1.N, s)
+	}
 	curveOrderByteSize := params.P.BitLen() / 8
 	rBytes, sBytes := r.Bytes(), s.Bytes()
 	signature := make([]byte, curveOrderByteSize*2)
@@ -638,6 +659,22 @@ func Sign(data []byte, key string) ([]byte, error) {
 	return signature, nil
 }
 
+// IsCanonicalSignature checks the signature is 64 bytes r+s with r and s in [1, N-1] and s in the low half of the curve order.
+// N-s verifies the same as s so a high s is a malleated copy of a low s signature.
+// NEO 2 consensus accepts both and neo-cli doesn't normalize s so this is a stricter policy than the chain, not a validity check
+func IsCanonicalSignature(signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	n := secp256r1.N
+	r := new(big.Int).SetBytes(signature[0:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	if r.Sign() == 0 || r.Cmp(n) >= 0 || s.Sign() == 0 {
+		return false
+	}
+	return s.Cmp(new(big.Int).Rsh(n, 1)) <= 0
+}
+
 func Verify(publicKey []byte, signature []byte, hash []byte) bool {
 	pub := PublicKey{}
 	pub.FromBytes(publicKey)
@@ -293,6 +293,41 @@ func TestPrivateKeyFromBytes(t *testing.T) {
 	t.Log("success PrivateKey FromBytes() on invaild vectors")
 }
 
+func TestValidatePrivateKey(t *testing.T) {
+	/* Zero */
+	err := ValidatePrivateKey(make([]byte, 32))
+	if err == nil {
+		t.Fatalf("ValidatePrivateKey(0): got success, expected error")
+	}
+	/* Curve order N */
+	err = ValidatePrivateKey(secp256r1.N.Bytes())
+	if err == nil {
+		t.Fatalf("ValidatePrivateKey(N): got success, expected error")
+	}
+	/* N-1 is the largest valid key */
+	err = ValidatePrivateKey(new(big.Int).Sub(secp256r1.N, big.NewInt(1)).Bytes())
+	if err != nil {
+		t.Fatalf("ValidatePrivateKey(N-1): got error %v, expected success", err)
+	}
+	/* Wrong length */
+	err = ValidatePrivateKey(keyPairVectors[0].priv_bytes[0:31])
+	if err == nil {
+		t.Fatalf("ValidatePrivateKey(short): got success, expected error")
+	}
+	/* Valid key */
+	err = ValidatePrivateKey(keyPairVectors[0].priv_bytes)
+	if err != nil {
+		t.Fatalf("ValidatePrivateKey(D): got error %v, expected success", err)
+	}
+
+	/* A wallet can't be made from an invalid key */
+	var priv PrivateKey
+	err = priv.FromBytes(secp256r1.N.Bytes())
+	if err == nil {
+		t.Fatalf("priv.FromBytes(N): got success, expected error")
+	}
+}
+
 func TestPrivateKeyToBytes(t *testing.T) {
 	var priv PrivateKey
 
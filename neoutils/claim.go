@@ -0,0 +1,67 @@
+package neoutils
+
+import (
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//ClaimGASRawTransaction builds and signs a claim transaction sending the claimable GAS of the spent NEO outputs in claims to the wallet.
+//amount must be the claimable GAS of those outputs. get both from get_claimable or calculate it with smartcontract.CalculateClaimableGAS
+//
+//GAS of NEO can only be claimed once the NEO is spent. to claim GAS of NEO that you hold
+//send the NEO first (to yourself or somebody else) with SendNativeAssetRawTransaction then claim once it's confirmed.
+//ClaimAndSendNEORawTransactions builds both when you already have spent coins to claim.
+func ClaimGASRawTransaction(wallet Wallet, claims []smartcontract.UTXO, amount float64, attributes map[smartcontract.TransactionAttribute][]byte) ([]byte, string, error) {
+	tx := smartcontract.NewClaimTransaction()
+
+	txData, err := smartcontract.NewScriptBuilder().GenerateClaimTransactionData(claims)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Data = txData
+
+	txAttributes, err := smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Attributes = txAttributes
+
+	//a claim transaction doesn't spend anything
+	tx.Inputs = []byte{0x00}
+
+	txOutputs, err := smartcontract.NewScriptBuilder().GenerateClaimTransactionOutput(smartcontract.ParseNEOAddress(wallet.Address), amount)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Outputs = txOutputs
+
+	signedData, err := Sign(tx.ToBytes(), bytesToHex(wallet.PrivateKey))
+	if err != nil {
+		return nil, "", err
+	}
+	signature := smartcontract.TransactionSignature{
+		SignedData: signedData,
+		PublicKey:  wallet.PublicKey,
+	}
+	txScripts := smartcontract.NewScriptBuilder().GenerateVerificationScripts([]interface{}{signature})
+
+	endPayload := []byte{}
+	endPayload = append(endPayload, tx.ToBytes()...)
+	endPayload = append(endPayload, txScripts...)
+
+	return endPayload, tx.ToTXID(), nil
+}
+
+//ClaimAndSendNEORawTransactions builds the two transactions of the claim and send flow.
+//the claim transaction claims GAS of NEO that is already spent and the send transaction moves NEO which makes its GAS claimable next time.
+//they don't depend on each other so they can be sent in any order
+func (n *NativeAsset) ClaimAndSendNEORawTransactions(wallet Wallet, amount float64, to smartcontract.NEOAddress, unspent smartcontract.Unspent, claims []smartcontract.UTXO, claimAmount float64) (claimTx []byte, claimTxID string, sendTx []byte, sendTxID string, err error) {
+	claimTx, claimTxID, err = ClaimGASRawTransaction(wallet, claims, claimAmount, nil)
+	if err != nil {
+		return nil, "", nil, "", err
+	}
+	sendTx, sendTxID, err = n.SendNativeAssetRawTransaction(wallet, smartcontract.NEO, amount, to, unspent, nil)
+	if err != nil {
+		return nil, "", nil, "", err
+	}
+	return claimTx, claimTxID, sendTx, sendTxID, nil
+}
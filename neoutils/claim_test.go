@@ -0,0 +1,75 @@
+package neoutils_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestClaimAndSendNEO(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.NEO: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 10},
+				},
+			},
+		},
+	}
+	claims := []smartcontract.UTXO{
+		{Index: 1, TXID: "0x9e2e2c0f0e1c8ab2f1b1e8ac5cbb8ee4d6c8ce2d5b7e3ae4b1e5b4f1e0f8b3a1", Value: 5},
+	}
+	to := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+
+	nativeAsset := neoutils.UseNativeAsset(smartcontract.NetworkFeeAmount(0))
+	claimTx, claimTxID, sendTx, sendTxID, err := nativeAsset.ClaimAndSendNEORawTransactions(*wallet, 10, to, unspent, claims, 0.125)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claim, err := smartcontract.DeserializeTransaction(claimTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim.Type != smartcontract.ClaimTransaction || claim.ToTXID() != claimTxID {
+		t.Fatalf("unexpected claim transaction %x", claimTx)
+	}
+	//1 claim [txid in little endian][index]
+	expectedData := "01" + "a1b3f8e0f1b4e5b1e43a7e5b2dcec8d6e48ebb5cace8b1f1b28a1c0e0f2c2e9e" + "0100"
+	if fmt.Sprintf("%x", claim.Data) != expectedData {
+		t.Fatalf("expected claims %v got %x", expectedData, claim.Data)
+	}
+	//no input and one GAS output of 0.125 to the wallet
+	expectedOutputs := "01" + "e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c60" + "20bcbe0000000000" + fmt.Sprintf("%x", []byte(smartcontract.ParseNEOAddress(wallet.Address)))
+	if fmt.Sprintf("%x", claim.Inputs) != "00" || fmt.Sprintf("%x", claim.Outputs) != expectedOutputs {
+		t.Fatalf("unexpected inputs %x or outputs %x", claim.Inputs, claim.Outputs)
+	}
+	invocationScript, err := claim.InvocationScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(claimTx[:len(claimTx)-len(claim.Script)])
+	if neoutils.Verify(wallet.PublicKey, invocationScript[1:], hash[:]) == false {
+		t.Fatal("invalid claim signature")
+	}
+
+	send, err := smartcontract.DeserializeTransaction(sendTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if send.Type != smartcontract.ContractTransaction || send.ToTXID() != sendTxID {
+		t.Fatalf("unexpected send transaction %x", sendTx)
+	}
+}
+
+func TestClaimGASRawTransactionWithoutClaims(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	_, _, err := neoutils.ClaimGASRawTransaction(*wallet, nil, 1, nil)
+	if err == nil {
+		t.Fatal("expected an error when there is nothing to claim")
+	}
+}
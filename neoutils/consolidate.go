@@ -0,0 +1,67 @@
+package neoutils
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//ConsolidateRawTransaction sends up to maxInputs of the smallest UTXOs of the asset back to the wallet in a single output.
+//spending them later needs fewer inputs so transactions stay small.
+//the network fee is taken from the consolidated amount so only GAS can be consolidated with a fee
+func (n *NativeAsset) ConsolidateRawTransaction(wallet Wallet, asset smartcontract.NativeAsset, unspent smartcontract.Unspent, maxInputs int) ([]byte, string, error) {
+	balance := unspent.Assets[asset]
+	if balance == nil || len(balance.UTXOs) < 2 {
+		return nil, "", fmt.Errorf("nothing to consolidate. at least 2 UTXOs of %v are needed", asset)
+	}
+	if maxInputs < 2 {
+		return nil, "", fmt.Errorf("maximum number of inputs must be at least 2")
+	}
+
+	utxos := append([]smartcontract.UTXO{}, balance.UTXOs...)
+	sort.SliceStable(utxos, func(i, j int) bool { return utxos[i].Value < utxos[j].Value })
+	if len(utxos) > maxInputs {
+		utxos = utxos[:maxInputs]
+	}
+	total := smartcontract.Fixed8(0)
+	for _, utxo := range utxos {
+		total = total.Add(smartcontract.NewFixed8(utxo.Value))
+	}
+	amount := total.Sub(smartcontract.NewFixed8(float64(n.NetworkFeeAmount)))
+	if amount <= 0 {
+		return nil, "", fmt.Errorf("%v of %v is not enough to pay the network fee", total, asset)
+	}
+
+	tx := smartcontract.NewContractTransaction()
+	txInputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInputFromUTXOs(utxos, asset, amount.Float64(), n.NetworkFeeAmount)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Inputs = txInputs
+	tx.Attributes = smartcontract.NewScriptBuilder().EmptyTransactionAttributes()
+
+	//the inputs and the output belong to the same address
+	self := smartcontract.ParseNEOAddress(wallet.Address)
+	txOutputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutputFromUTXOs(self, self, utxos, asset, amount.Float64(), n.NetworkFeeAmount)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Outputs = txOutputs
+
+	signedData, err := Sign(tx.ToBytes(), bytesToHex(wallet.PrivateKey))
+	if err != nil {
+		return nil, "", err
+	}
+	signature := smartcontract.TransactionSignature{
+		SignedData: signedData,
+		PublicKey:  wallet.PublicKey,
+	}
+	txScripts := smartcontract.NewScriptBuilder().GenerateVerificationScripts([]interface{}{signature})
+
+	endPayload := []byte{}
+	endPayload = append(endPayload, tx.ToBytes()...)
+	endPayload = append(endPayload, txScripts...)
+
+	return endPayload, tx.ToTXID(), nil
+}
@@ -0,0 +1,58 @@
+package neoutils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestConsolidateRawTransaction(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	utxos := []smartcontract.UTXO{}
+	for i := 0; i < 6; i++ {
+		utxos = append(utxos, smartcontract.UTXO{Index: i, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: float64(i + 1)})
+	}
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{UTXOs: utxos},
+		},
+	}
+
+	nativeAsset := neoutils.UseNativeAsset(smartcontract.NetworkFeeAmount(0.5))
+	raw, txID, err := nativeAsset.ConsolidateRawTransaction(*wallet, smartcontract.GAS, unspent, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := smartcontract.DeserializeTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.ToTXID() != txID {
+		t.Fatalf("expected %v got %v", txID, tx.ToTXID())
+	}
+	//the five smallest UTXOs 1 + 2 + 3 + 4 + 5 = 15 GAS
+	if tx.Inputs[0] != 5 || len(tx.Inputs) != 1+5*34 {
+		t.Fatalf("expected 5 inputs got %x", tx.Inputs)
+	}
+	//a single output of 15 - 0.5 GAS back to the wallet
+	expectedOutputs := "01" + "e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c60" + "803e6d5600000000" + fmt.Sprintf("%x", []byte(smartcontract.ParseNEOAddress(wallet.Address)))
+	if fmt.Sprintf("%x", tx.Outputs) != expectedOutputs {
+		t.Fatalf("expected %v got %x", expectedOutputs, tx.Outputs)
+	}
+	invocationScript, err := tx.InvocationScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if neoutils.Verify(wallet.PublicKey, invocationScript[1:], tx.SigningHash()) == false {
+		t.Fatal("invalid signature")
+	}
+
+	//NEO can't pay the network fee
+	unspent.Assets[smartcontract.NEO] = unspent.Assets[smartcontract.GAS]
+	_, _, err = nativeAsset.ConsolidateRawTransaction(*wallet, smartcontract.NEO, unspent, 5)
+	if err == nil {
+		t.Fatal("expected an error consolidating NEO with a network fee")
+	}
+}
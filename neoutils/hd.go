@@ -0,0 +1,106 @@
+package neoutils
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/o3labs/neo-utils/neoutils/btckey"
+)
+
+//HardenedKeyStart is the first hardened child index. index + HardenedKeyStart is written as index' in a path
+const HardenedKeyStart = uint32(0x80000000)
+
+//NEOCoinType is the registered SLIP-44 coin type of NEO
+const NEOCoinType = uint32(888)
+
+//an extended private key. key is 32 bytes and chainCode is 32 bytes
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+//HD keys of NEO are on secp256r1 so derivation follows SLIP-0010 which is BIP32 for curves other than secp256k1
+//https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+func newMasterKey(seed []byte) extendedKey {
+	n := elliptic.P256().Params().N
+	data := seed
+	for {
+		mac := hmac.New(sha512.New, []byte("Nist256p1 seed"))
+		mac.Write(data)
+		i := mac.Sum(nil)
+		k := new(big.Int).SetBytes(i[:32])
+		if k.Sign() > 0 && k.Cmp(n) < 0 {
+			return extendedKey{key: i[:32], chainCode: i[32:]}
+		}
+		data = i
+	}
+}
+
+func (e extendedKey) child(index uint32) (extendedKey, error) {
+	n := elliptic.P256().Params().N
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+
+	data := []byte{}
+	if index >= HardenedKeyStart {
+		data = append([]byte{0x00}, e.key...)
+	} else {
+		var priv btckey.PrivateKey
+		err := priv.FromBytes(e.key)
+		if err != nil {
+			return extendedKey{}, err
+		}
+		data = priv.PublicKey.ToBytes()
+	}
+	data = append(data, indexBytes...)
+
+	k := new(big.Int).SetBytes(e.key)
+	for {
+		mac := hmac.New(sha512.New, e.chainCode)
+		mac.Write(data)
+		i := mac.Sum(nil)
+		il := new(big.Int).SetBytes(i[:32])
+		childKey := new(big.Int).Add(il, k)
+		childKey.Mod(childKey, n)
+		if il.Cmp(n) < 0 && childKey.Sign() != 0 {
+			key := make([]byte, 32)
+			childKey.FillBytes(key)
+			return extendedKey{key: key, chainCode: i[32:]}, nil
+		}
+		//invalid key. try again with the right half of I
+		data = append(append([]byte{0x01}, i[32:]...), indexBytes...)
+	}
+}
+
+func deriveKey(seed []byte, path []uint32) (extendedKey, error) {
+	key := newMasterKey(seed)
+	for _, index := range path {
+		var err error
+		key, err = key.child(index)
+		if err != nil {
+			return extendedKey{}, err
+		}
+	}
+	return key, nil
+}
+
+//DeriveAccount returns the account at m/44'/888'/0'/0/index of the seed e.g. the seed of a BIP39 mnemonic.
+//the same seed always derives the same accounts
+func DeriveAccount(seed []byte, index uint32) (*Wallet, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed must be between 16 and 64 bytes but got %v", len(seed))
+	}
+	if index >= HardenedKeyStart {
+		return nil, fmt.Errorf("account index must be less than %v", HardenedKeyStart)
+	}
+	path := []uint32{44 + HardenedKeyStart, NEOCoinType + HardenedKeyStart, HardenedKeyStart, 0, index}
+	key, err := deriveKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return GenerateFromPrivateKey(bytesToHex(key.key))
+}
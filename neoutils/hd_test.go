@@ -0,0 +1,50 @@
+package neoutils
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+//test vector 1 for nist256p1 in SLIP-0010
+func TestDeriveKeySLIP10(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := deriveKey(seed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(key.key) != "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2" ||
+		hex.EncodeToString(key.chainCode) != "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea" {
+		t.Fatalf("unexpected master key %x %x", key.key, key.chainCode)
+	}
+	key, err = deriveKey(seed, []uint32{HardenedKeyStart})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(key.key) != "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c" ||
+		hex.EncodeToString(key.chainCode) != "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11" {
+		t.Fatalf("unexpected m/0' key %x %x", key.key, key.chainCode)
+	}
+}
+
+func TestDeriveAccount(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	expected := []string{
+		"AKqJMA7ZxP7ykuZg3Z7cqxsM49euaaYrtX",
+		"AHytyEtAVkHiCsT66jwhUYn3amK1CUPtRZ",
+		"AZMmaUPVqwZQxK5XY4J6cLGbjTmoR6N5zs",
+	}
+	for i, address := range expected {
+		wallet, err := DeriveAccount(seed, uint32(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wallet.Address != address {
+			t.Fatalf("account %v expected %v got %v", i, address, wallet.Address)
+		}
+	}
+
+	_, err := DeriveAccount(seed[:8], 0)
+	if err == nil {
+		t.Fail()
+	}
+}
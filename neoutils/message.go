@@ -0,0 +1,51 @@
+package neoutils
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+//a signed message is wrapped the same way NEO wallets do for dApps
+//010001f0 + [var int length] + [message] + 0000
+//the wrapped message can never be deserialized as a valid transaction so a message signature can't be replayed as a transaction signature
+var (
+	messagePrefix = []byte{0x01, 0x00, 0x01, 0xf0}
+	messageSuffix = []byte{0x00, 0x00}
+)
+
+func wrapMessage(message []byte) []byte {
+	length := len(message)
+	b := append([]byte{}, messagePrefix...)
+	switch {
+	case length < 0xfd:
+		b = append(b, byte(length))
+	case length <= 0xffff:
+		lengthBytes := make([]byte, 2)
+		binary.LittleEndian.PutUint16(lengthBytes, uint16(length))
+		b = append(append(b, 0xfd), lengthBytes...)
+	default:
+		lengthBytes := make([]byte, 4)
+		binary.LittleEndian.PutUint32(lengthBytes, uint32(length))
+		b = append(append(b, 0xfe), lengthBytes...)
+	}
+	b = append(b, message...)
+	return append(b, messageSuffix...)
+}
+
+//SignMessage signs an arbitrary message with the private key for off-chain authentication. it returns nil when the key is invalid
+func SignMessage(privateKey []byte, message []byte) []byte {
+	signature, err := Sign(wrapMessage(message), bytesToHex(privateKey))
+	if err != nil {
+		return nil
+	}
+	return signature
+}
+
+//VerifyMessage verifies a signature made by SignMessage
+func VerifyMessage(publicKey []byte, message []byte, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	hash := sha256.Sum256(wrapMessage(message))
+	return Verify(publicKey, signature, hash[:])
+}
@@ -0,0 +1,40 @@
+package neoutils_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils"
+)
+
+func TestSignMessage(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	message := []byte("login to example.com at 1539000000")
+
+	signature := neoutils.SignMessage(wallet.PrivateKey, message)
+	if len(signature) != 64 {
+		t.Fatalf("expected 64 bytes signature got %x", signature)
+	}
+	if neoutils.VerifyMessage(wallet.PublicKey, message, signature) == false {
+		t.Fatal("signature of the message doesn't verify")
+	}
+
+	tampered := []byte("login to example.com at 1539000001")
+	if neoutils.VerifyMessage(wallet.PublicKey, tampered, signature) == true {
+		t.Fatal("signature must not verify a different message")
+	}
+
+	other, _ := neoutils.NewWallet()
+	if neoutils.VerifyMessage(other.PublicKey, message, signature) == true {
+		t.Fatal("signature must not verify with another public key")
+	}
+
+	//a truncated signature is rejected
+	if neoutils.VerifyMessage(wallet.PublicKey, message, signature[:63]) == true {
+		t.Fail()
+	}
+
+	long := make([]byte, 300)
+	if neoutils.VerifyMessage(wallet.PublicKey, long, neoutils.SignMessage(wallet.PrivateKey, long)) == false {
+		t.Fatal("signature of a long message doesn't verify")
+	}
+}
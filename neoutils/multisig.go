@@ -2,10 +2,13 @@
 package neoutils
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"sort"
 
 	"github.com/o3labs/neo-utils/neoutils/btckey"
+	"github.com/o3labs/neo-utils/neoutils/nep6"
 	"github.com/o3labs/neo-utils/neoutils/smartcontract"
 )
 
@@ -15,34 +18,281 @@ type MultiSigInterface interface {
 
 type MultiSig struct{}
 
-var _ MultiSigInterface = (*MultiSig)(nil)
+//maximum number of public keys CHECKMULTISIG accepts
+const maxMultiSigPublicKeys = 1024
 
-func (m *MultiSig) CreateMultiSigRedeemScript(numerOfRequiredSignature int, publicKeys [][]byte) ([]byte, error) {
-	numberOfPublicKeys := len(publicKeys)
-	if numberOfPublicKeys <= 1 {
-		return nil, fmt.Errorf("Number of required Signature must be more than one")
-	}
-	if numerOfRequiredSignature > numberOfPublicKeys {
-		return nil, fmt.Errorf("Number of required Signature is more than public keys provided.")
-	}
+var _ MultiSigInterface = (*MultiSig)(nil)
 
-	//sort public key
+//public keys in a multisig redeem script are sorted by X coordinate in ascending order
+//each key is either compressed (33 bytes) or uncompressed (65 bytes) and must be a point on the curve
+func sortPublicKeys(publicKeys [][]byte) ([]btckey.PublicKey, error) {
 	keys := []btckey.PublicKey{}
 	for _, pb := range publicKeys {
 		publicKey := btckey.PublicKey{}
-		publicKey.FromBytes(pb)
+		err := publicKey.FromBytes(pb)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid public key %x: %v", pb, err)
+		}
 		keys = append(keys, publicKey)
 	}
 
 	//https://golang.org/pkg/math/big/#Int.Cmp
 	sort.SliceStable(keys, func(i, j int) bool { return keys[i].Point.X.Cmp(keys[j].Point.X) == -1 })
+	return keys, nil
+}
+
+func (m *MultiSig) CreateMultiSigRedeemScript(numerOfRequiredSignature int, publicKeys [][]byte) ([]byte, error) {
+	numberOfPublicKeys := len(publicKeys)
+	if numberOfPublicKeys < 1 || numberOfPublicKeys > maxMultiSigPublicKeys {
+		return nil, fmt.Errorf("Number of public keys must be between 1 and %v", maxMultiSigPublicKeys)
+	}
+	if numerOfRequiredSignature < 1 {
+		return nil, fmt.Errorf("Number of required Signature must be at least one")
+	}
+	if numerOfRequiredSignature > numberOfPublicKeys {
+		return nil, fmt.Errorf("Number of required Signature is more than public keys provided.")
+	}
 
+	//an uncompressed key is put in the script compressed
+	keys, err := sortPublicKeys(publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	//PUSH m + n x (PUSHBYTES33 [public key]) + PUSH n + CHECKMULTISIG
+	//m and n are PUSH1-PUSH16 up to 16 and a byte array over 16
 	sb := smartcontract.NewScriptBuilder()
-	sb.Push(numerOfRequiredSignature)
+	err = sb.Push(numerOfRequiredSignature)
+	if err != nil {
+		return nil, err
+	}
 	for _, publicKey := range keys {
-		sb.Push(publicKey.ToBytes())
+		err := sb.Push(publicKey.ToBytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = sb.Push(numberOfPublicKeys)
+	if err != nil {
+		return nil, err
 	}
-	sb.Push(numberOfPublicKeys)
 	sb.PushOpCode(smartcontract.CHECKMULTISIG)
 	return sb.ToBytes(), nil
 }
+
+//ParseMultiSigScript returns the number of required signatures and the public keys of a multisig redeem script
+//the public keys are in the order they are in the script. it's the reverse of CreateMultiSigRedeemScript
+func ParseMultiSigScript(script []byte) (m int, pubkeys [][]byte, err error) {
+	kind, m, _, pubkeys, err := smartcontract.ClassifyVerificationScript(script)
+	if err != nil {
+		return 0, nil, err
+	}
+	if kind != smartcontract.VerificationScriptMultiSig {
+		return 0, nil, fmt.Errorf("redeem script is not a multisig script")
+	}
+	return m, pubkeys, nil
+}
+
+//MultiSigWallet is a wallet that needs signatures from a number of public keys to spend from its address
+type MultiSigWallet struct {
+	NumberOfRequiredSignatures int
+	PublicKeys                 [][]byte
+	RedeemScript               []byte //verification script of the multisig contract
+}
+
+func NewMultiSigWallet(numberOfRequiredSignatures int, publicKeys [][]byte) (*MultiSigWallet, error) {
+	multisig := MultiSig{}
+	redeemScript, err := multisig.CreateMultiSigRedeemScript(numberOfRequiredSignatures, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiSigWallet{
+		NumberOfRequiredSignatures: numberOfRequiredSignatures,
+		PublicKeys:                 publicKeys,
+		RedeemScript:               redeemScript,
+	}, nil
+}
+
+//Address returns the NEO address of the multisig contract
+func (w *MultiSigWallet) Address() string {
+	return VMCodeToNEOAddress(w.RedeemScript)
+}
+
+//NEP6Contract returns the contract object to save the multisig account in a NEP-6 wallet
+func (w *MultiSigWallet) NEP6Contract() (nep6.NEP6Contract, error) {
+	return nep6.NewNEP6Contract(w.RedeemScript)
+}
+
+func (w *MultiSigWallet) containsPublicKey(publicKey []byte) bool {
+	keys, err := sortPublicKeys(w.PublicKeys)
+	if err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if bytes.Equal(key.ToBytes(), publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+//MultiSigSigningSession collects signatures for a transaction from the owners of a multisig wallet one at a time
+type MultiSigSigningSession struct {
+	Wallet     *MultiSigWallet
+	Data       []byte            //unsigned transaction
+	signatures map[string][]byte //compressed public key in hex -> signed data
+}
+
+func (w *MultiSigWallet) NewSigningSession(unsignedTransaction []byte) *MultiSigSigningSession {
+	return &MultiSigSigningSession{
+		Wallet:     w,
+		Data:       unsignedTransaction,
+		signatures: map[string][]byte{},
+	}
+}
+
+//Sign signs the transaction with a wallet that is one of the owners of the multisig wallet
+func (s *MultiSigSigningSession) Sign(wallet Wallet) error {
+	signedData, err := Sign(s.Data, bytesToHex(wallet.PrivateKey))
+	if err != nil {
+		return err
+	}
+	return s.AddSignature(wallet.PublicKey, signedData)
+}
+
+//AddSignature adds a signature that was made somewhere else. the signature is verified before it's added
+func (s *MultiSigSigningSession) AddSignature(publicKey []byte, signedData []byte) error {
+	pb := btckey.PublicKey{}
+	err := pb.FromBytes(publicKey)
+	if err != nil {
+		return err
+	}
+	compressed := pb.ToBytes()
+	if s.Wallet.containsPublicKey(compressed) == false {
+		return fmt.Errorf("public key %x is not one of the multisig public keys", compressed)
+	}
+	hash := sha256.Sum256(s.Data)
+	if len(signedData) != 64 || Verify(compressed, signedData, hash[:]) == false {
+		return fmt.Errorf("invalid signature for public key %x", compressed)
+	}
+	key := bytesToHex(compressed)
+	if _, exist := s.signatures[key]; exist {
+		return fmt.Errorf("public key %x has already signed", compressed)
+	}
+	s.signatures[key] = signedData
+	return nil
+}
+
+//RemainingSignatures returns the number of signatures still needed to spend from the multisig wallet
+func (s *MultiSigSigningSession) RemainingSignatures() int {
+	remaining := s.Wallet.NumberOfRequiredSignatures - len(s.signatures)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+//Progress returns how many signatures are collected so far and how many the multisig wallet needs
+//e.g. 1, 2 for "1 of 2 signatures collected". collected can be more than required when more owners sign
+func (s *MultiSigSigningSession) Progress() (collected int, required int) {
+	return len(s.signatures), s.Wallet.NumberOfRequiredSignatures
+}
+
+func (s *MultiSigSigningSession) IsComplete() bool {
+	return s.RemainingSignatures() == 0
+}
+
+//Witness returns the verification scripts of the transaction ready to be appended to the unsigned transaction
+//signatures are in the same order as the public keys in the redeem script
+func (s *MultiSigSigningSession) Witness() ([]byte, error) {
+	if s.IsComplete() == false {
+		return nil, fmt.Errorf("need %v more signatures", s.RemainingSignatures())
+	}
+	invocationScript := []byte{}
+	count := 0
+	keys, err := sortPublicKeys(s.Wallet.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		signedData, exist := s.signatures[bytesToHex(key.ToBytes())]
+		if exist == false {
+			continue
+		}
+		//0x40 = PUSHBYTES64
+		invocationScript = append(invocationScript, byte(len(signedData)))
+		invocationScript = append(invocationScript, signedData...)
+		count += 1
+		if count == s.Wallet.NumberOfRequiredSignatures {
+			break
+		}
+	}
+	script := smartcontract.TransactionValidationScript{
+		StackScript:  invocationScript,
+		RedeemScript: s.Wallet.RedeemScript,
+	}
+	return smartcontract.NewScriptBuilder().GenerateVerificationScripts([]interface{}{script}), nil
+}
+
+//OrderMultiSigSignatures matches each signature to the public key it verifies against
+//and returns the signatures in the same order as the public keys in the redeem script.
+//CHECKMULTISIG walks the signatures and the public keys in one direction so signatures in signing order may fail
+func OrderMultiSigSignatures(redeemScript []byte, unsignedTransaction []byte, signatures [][]byte) ([][]byte, error) {
+	kind, _, _, publicKeys, err := smartcontract.ClassifyVerificationScript(redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	if kind != smartcontract.VerificationScriptMultiSig {
+		return nil, fmt.Errorf("redeem script is not a multisig script")
+	}
+
+	hash := sha256.Sum256(unsignedTransaction)
+	signatureOfKey := make([][]byte, len(publicKeys))
+	for _, signature := range signatures {
+		matched := false
+		for i, publicKey := range publicKeys {
+			if len(signature) != 64 || Verify(publicKey, signature, hash[:]) == false {
+				continue
+			}
+			if signatureOfKey[i] != nil {
+				return nil, fmt.Errorf("public key %x has more than one signature", publicKey)
+			}
+			signatureOfKey[i] = signature
+			matched = true
+			break
+		}
+		if matched == false {
+			return nil, fmt.Errorf("signature %x doesn't match any public key of the redeem script", signature)
+		}
+	}
+
+	ordered := [][]byte{}
+	for _, signature := range signatureOfKey {
+		if signature != nil {
+			ordered = append(ordered, signature)
+		}
+	}
+	return ordered, nil
+}
+
+//WitnessFromSignatures returns the verification scripts of the transaction from signatures given in any order
+func (w *MultiSigWallet) WitnessFromSignatures(unsignedTransaction []byte, signatures [][]byte) ([]byte, error) {
+	ordered, err := OrderMultiSigSignatures(w.RedeemScript, unsignedTransaction, signatures)
+	if err != nil {
+		return nil, err
+	}
+	if len(ordered) < w.NumberOfRequiredSignatures {
+		return nil, fmt.Errorf("need %v more signatures", w.NumberOfRequiredSignatures-len(ordered))
+	}
+	invocationScript := []byte{}
+	for _, signedData := range ordered[:w.NumberOfRequiredSignatures] {
+		//0x40 = PUSHBYTES64
+		invocationScript = append(invocationScript, byte(len(signedData)))
+		invocationScript = append(invocationScript, signedData...)
+	}
+	script := smartcontract.TransactionValidationScript{
+		StackScript:  invocationScript,
+		RedeemScript: w.RedeemScript,
+	}
+	return smartcontract.NewScriptBuilder().GenerateVerificationScripts([]interface{}{script}), nil
+}
@@ -1,12 +1,16 @@
 package neoutils_test
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"log"
 	"sort"
 	"testing"
 
 	"github.com/o3labs/neo-utils/neoutils"
 	"github.com/o3labs/neo-utils/neoutils/btckey"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
 )
 
 func TestGenerateMultiSigAddress(t *testing.T) {
@@ -33,6 +37,37 @@ func TestGenerateMultiSigAddress(t *testing.T) {
 	}
 }
 
+func TestParseMultiSigScript(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	pb2 := "024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff0"
+
+	multisign := neoutils.MultiSig{}
+	vmCode, err := multisign.CreateMultiSigRedeemScript(2, [][]byte{neoutils.HexTobytes(pb1), neoutils.HexTobytes(pb2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, pubKeys, err := neoutils.ParseMultiSigScript(vmCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//public keys come back sorted the way the redeem script has them
+	if m != 2 || len(pubKeys) != 2 || neoutils.BytesToHex(pubKeys[0]) != pb2 || neoutils.BytesToHex(pubKeys[1]) != pb1 {
+		t.Fatalf("unexpected %v of %x", m, pubKeys)
+	}
+
+	//recreating the script from the parsed values gives the same address
+	recreated, _ := multisign.CreateMultiSigRedeemScript(m, pubKeys)
+	if neoutils.VMCodeToNEOAddress(recreated) != "AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2" {
+		t.Fatalf("unexpected address %v", neoutils.VMCodeToNEOAddress(recreated))
+	}
+
+	//single signature script
+	_, _, err = neoutils.ParseMultiSigScript(neoutils.HexTobytes("21" + pb1 + "ac"))
+	if err == nil {
+		t.Fatal("expected an error for a single signature script")
+	}
+}
+
 func TestSortPublicKeys(t *testing.T) {
 	p1Hex := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
 	p2Hex := "024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff0"
@@ -54,3 +89,362 @@ func TestSortPublicKeys(t *testing.T) {
 	//correct order is p2, p3, p1
 
 }
+
+func TestNewMultiSigWallet(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	pb2 := "024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff0"
+	pubKeys := [][]byte{neoutils.HexTobytes(pb1), neoutils.HexTobytes(pb2)}
+
+	wallet, err := neoutils.NewMultiSigWallet(2, pubKeys)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	log.Printf("redeem script %x", wallet.RedeemScript)
+	if wallet.Address() != "AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2" {
+		t.Fail()
+	}
+}
+
+func TestNewMultiSigWalletInvalid(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	_, err := neoutils.NewMultiSigWallet(2, [][]byte{neoutils.HexTobytes(pb1)})
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestNewMultiSigWalletUncompressedPublicKey(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	pb2 := "024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff0"
+	key := btckey.PublicKey{}
+	err := key.FromBytes(neoutils.HexTobytes(pb2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed := key.ToBytesUncompressed()
+	if len(uncompressed) != 65 {
+		t.Fatalf("expected 65 bytes got %x", uncompressed)
+	}
+
+	//the same address as with the compressed key
+	wallet, err := neoutils.NewMultiSigWallet(2, [][]byte{neoutils.HexTobytes(pb1), uncompressed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.Address() != "AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2" {
+		t.Fatalf("unexpected address %v", wallet.Address())
+	}
+}
+
+func TestNewMultiSigWalletInvalidPublicKey(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	for _, invalid := range []string{
+		//33 bytes with an invalid prefix
+		"05e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986",
+		//x is not on the curve
+		"02ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		//too short
+		"02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a89",
+	} {
+		_, err := neoutils.NewMultiSigWallet(1, [][]byte{neoutils.HexTobytes(pb1), neoutils.HexTobytes(invalid)})
+		if err == nil {
+			t.Fatalf("expected an error for %v", invalid)
+		}
+	}
+}
+
+func TestMultiSigSigningSession(t *testing.T) {
+	wallet1, _ := neoutils.NewWallet()
+	wallet2, _ := neoutils.NewWallet()
+	wallet3, _ := neoutils.NewWallet()
+
+	pubKeys := [][]byte{wallet1.PublicKey, wallet2.PublicKey, wallet3.PublicKey}
+	multisigWallet, err := neoutils.NewMultiSigWallet(2, pubKeys)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+
+	unsignedTransaction := neoutils.HexTobytes("8000000001e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c6000000000")
+	session := multisigWallet.NewSigningSession(unsignedTransaction)
+	if session.RemainingSignatures() != 2 {
+		t.Fail()
+		return
+	}
+
+	err = session.Sign(*wallet3)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	if session.RemainingSignatures() != 1 {
+		t.Fail()
+		return
+	}
+
+	//signing twice with the same key must not count
+	err = session.Sign(*wallet3)
+	if err == nil || session.RemainingSignatures() != 1 {
+		t.Fail()
+		return
+	}
+
+	_, err = session.Witness()
+	if err == nil {
+		t.Fail()
+		return
+	}
+
+	err = session.Sign(*wallet1)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	if session.IsComplete() == false {
+		t.Fail()
+		return
+	}
+
+	witness, err := session.Witness()
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	log.Printf("witness %x", witness)
+	//1 witness, 2 x (PUSHBYTES64 + signature) = 130 bytes invocation script
+	if witness[0] != 0x01 || witness[1] != 130 || witness[2] != 0x40 || witness[2+65] != 0x40 {
+		t.Fail()
+		return
+	}
+	if neoutils.BytesToHex(witness[len(witness)-len(multisigWallet.RedeemScript):]) != neoutils.BytesToHex(multisigWallet.RedeemScript) {
+		t.Fail()
+	}
+}
+
+func TestMultiSigSigningSessionNotOwner(t *testing.T) {
+	wallet1, _ := neoutils.NewWallet()
+	wallet2, _ := neoutils.NewWallet()
+	stranger, _ := neoutils.NewWallet()
+
+	multisigWallet, _ := neoutils.NewMultiSigWallet(2, [][]byte{wallet1.PublicKey, wallet2.PublicKey})
+	session := multisigWallet.NewSigningSession([]byte{0x80, 0x00})
+	err := session.Sign(*stranger)
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestCreateMultiSigRedeemScriptOneOfOne(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	multisign := neoutils.MultiSig{}
+	vmCode, err := multisign.CreateMultiSigRedeemScript(1, [][]byte{neoutils.HexTobytes(pb1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//PUSH1 PUSHBYTES33 [public key] PUSH1 CHECKMULTISIG
+	expected := "51" + "21" + pb1 + "51" + "ae"
+	if fmt.Sprintf("%x", vmCode) != expected {
+		t.Fatalf("expected %v got %x", expected, vmCode)
+	}
+}
+
+func TestCreateMultiSigRedeemScriptSeventeenOfTwenty(t *testing.T) {
+	pubKeys := [][]byte{}
+	for i := 0; i < 20; i++ {
+		wallet, _ := neoutils.NewWallet()
+		pubKeys = append(pubKeys, wallet.PublicKey)
+	}
+	multisign := neoutils.MultiSig{}
+	vmCode, err := multisign.CreateMultiSigRedeemScript(17, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//17 and 20 can't use PUSH1-PUSH16 so they're pushed as one byte arrays
+	if fmt.Sprintf("%x", vmCode[:2]) != "0111" {
+		t.Fatalf("expected m to be pushed as 0111 got %x", vmCode[:2])
+	}
+	offset := 2
+	for i := 0; i < 20; i++ {
+		if vmCode[offset] != 0x21 {
+			t.Fatalf("expected PUSHBYTES33 at %v got %x", offset, vmCode[offset])
+		}
+		offset += 1 + 33
+	}
+	if fmt.Sprintf("%x", vmCode[offset:]) != "0114ae" {
+		t.Fatalf("expected n and CHECKMULTISIG 0114ae got %x", vmCode[offset:])
+	}
+}
+
+func TestCreateMultiSigRedeemScriptInvalid(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	multisign := neoutils.MultiSig{}
+	_, err := multisign.CreateMultiSigRedeemScript(0, [][]byte{neoutils.HexTobytes(pb1)})
+	if err == nil {
+		t.Fail()
+	}
+	_, err = multisign.CreateMultiSigRedeemScript(1, [][]byte{})
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestCreateMultiSigRedeemScriptThreeOfEighteen(t *testing.T) {
+	//public keys of private keys 1 to 18
+	pubKeys := [][]byte{}
+	for _, pb := range []string{
+		"036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296",
+		"037cf27b188d034f7e8a52380304b51ac3c08969e277f21b35a60b48fc47669978",
+		"025ecbe4d1a6330a44c8f7ef951d4bf165e6c6b721efada985fb41661bc6e7fd6c",
+		"02e2534a3532d08fbba02dde659ee62bd0031fe2db785596ef509302446b030852",
+		"0251590b7a515140d2d784c85608668fdfef8c82fd1f5be52421554a0dc3d033ed",
+		"02b01a172a76a4602c92d3242cb897dde3024c740debb215b4c6b0aae93c2291a9",
+		"028e533b6fa0bf7b4625bb30667c01fb607ef9f8b8a80fef5b300628703187b2a3",
+		"0262d9779dbee9b0534042742d3ab54cadc1d238980fce97dbb4dd9dc1db6fb393",
+		"02ea68d7b6fedf0b71878938d51d71f8729e0acb8c2c6df8b3d79e8a4b90949ee0",
+		"03cef66d6b2a3a993e591214d1ea223fb545ca6c471c48306e4c36069404c5723f",
+		"023ed113b7883b4c590638379db0c21cda16742ed0255048bf433391d374bc21d1",
+		"03741dd5bda817d95e4626537320e5d55179983028b2f82c99d500c5ee8624e3c4",
+		"02177c837ae0ac495a61805df2d85ee2fc792e284b65ead58a98e15d9d46072c01",
+		"0354e77a001c3862b97a76647f4336df3cf126acbe7a069c5e5709277324d2920b",
+		"02f0454dc6971abae7adfb378999888265ae03af92de3a0ef163668c63e59b9d5f",
+		"0276a94d138a6b41858b821c629836315fcd28392eff6ca038a5eb4787e1277c6e",
+		"0247776904c0f1cc3a9c0984b66f75301a5fa68678f0d64af8ba1abce34738a73e",
+		"021057e0ab5780f470defc9378d1c7c87437bb4c6f9ea55c63d936266dbd781fda",
+	} {
+		pubKeys = append(pubKeys, neoutils.HexTobytes(pb))
+	}
+
+	wallet, err := neoutils.NewMultiSigWallet(3, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Printf("redeem script %x", wallet.RedeemScript)
+
+	//PUSH3 ... PUSHBYTES1 0x12 CHECKMULTISIG
+	script := wallet.RedeemScript
+	if script[0] != 0x53 || fmt.Sprintf("%x", script[len(script)-3:]) != "0112ae" {
+		t.Fatalf("invalid m or n push %x", script)
+	}
+
+	//expected address follows neo-cli Contract.CreateMultiSigRedeemScript. keys sorted by X then EmitPush(m), keys, EmitPush(n)
+	//script hash 0xef7e6fb5d001161105324ed22cf7fc630b2cfeb8
+	if wallet.Address() != "AYe2TyiXnm2bzhV8gvGX7XU3ZpkXqweEXy" {
+		t.Fatalf("unexpected address %v", wallet.Address())
+	}
+
+	kind, m, n, _, err := smartcontract.ClassifyVerificationScript(script)
+	if err != nil || kind != smartcontract.VerificationScriptMultiSig || m != 3 || n != 18 {
+		t.Fatalf("%v %v of %v %v", kind, m, n, err)
+	}
+}
+
+func TestWitnessFromSignaturesOutOfOrder(t *testing.T) {
+	wallets := []*neoutils.Wallet{}
+	pubKeys := [][]byte{}
+	for i := 0; i < 3; i++ {
+		wallet, _ := neoutils.NewWallet()
+		wallets = append(wallets, wallet)
+		pubKeys = append(pubKeys, wallet.PublicKey)
+	}
+	multisigWallet, err := neoutils.NewMultiSigWallet(2, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, scriptKeys, _ := smartcontract.ClassifyVerificationScript(multisigWallet.RedeemScript)
+
+	unsignedTransaction := neoutils.HexTobytes("800000014a4dfb91023b1b2086029e03af739d9ceab35fffa8d528de9a6fee3f35da0c4c0000")
+	signatures := [][]byte{}
+	//sign with the key that comes last in the redeem script first
+	for i := len(scriptKeys) - 1; i >= 1; i-- {
+		for _, wallet := range wallets {
+			if fmt.Sprintf("%x", wallet.PublicKey) != fmt.Sprintf("%x", scriptKeys[i]) {
+				continue
+			}
+			signedData, _ := neoutils.Sign(unsignedTransaction, fmt.Sprintf("%x", wallet.PrivateKey))
+			signatures = append(signatures, signedData)
+		}
+	}
+
+	witness, err := multisigWallet.WitnessFromSignatures(unsignedTransaction, signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//[count] + [invocation length] + 2 x ([0x40] + [signature])
+	invocationScript := witness[2 : 2+130]
+	hash := sha256.Sum256(unsignedTransaction)
+	if neoutils.Verify(scriptKeys[1], invocationScript[1:65], hash[:]) == false {
+		t.Fatal("first signature must be from the second key of the redeem script")
+	}
+	if neoutils.Verify(scriptKeys[2], invocationScript[66:130], hash[:]) == false {
+		t.Fatal("second signature must be from the third key of the redeem script")
+	}
+
+	_, err = multisigWallet.WitnessFromSignatures(unsignedTransaction, signatures[:1])
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestMultiSigSigningSessionProgress(t *testing.T) {
+	wallet1, _ := neoutils.NewWallet()
+	wallet2, _ := neoutils.NewWallet()
+	wallet3, _ := neoutils.NewWallet()
+
+	multisigWallet, err := neoutils.NewMultiSigWallet(2, [][]byte{wallet1.PublicKey, wallet2.PublicKey, wallet3.PublicKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := multisigWallet.NewSigningSession(neoutils.HexTobytes("8000000001e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c6000000000"))
+
+	expected := [][2]int{{1, 2}, {2, 2}}
+	for i, wallet := range []*neoutils.Wallet{wallet2, wallet3} {
+		err := session.Sign(*wallet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		collected, required := session.Progress()
+		if collected != expected[i][0] || required != expected[i][1] {
+			t.Fatalf("expected %v/%v got %v/%v", expected[i][0], expected[i][1], collected, required)
+		}
+	}
+}
+
+func TestMultiSigWalletNEP6Contract(t *testing.T) {
+	pb1 := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	pb2 := "024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff0"
+	wallet, err := neoutils.NewMultiSigWallet(2, [][]byte{neoutils.HexTobytes(pb1), neoutils.HexTobytes(pb2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract, err := wallet.NEP6Contract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(contract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"script":"5221024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff02102e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a898652ae","parameters":[{"name":"parameter0","type":"Signature"},{"name":"parameter1","type":"Signature"}]}`
+	if string(b) != expected {
+		t.Fatalf("expected %v got %s", expected, b)
+	}
+
+	single, err := neoutils.GenerateFromWIF("L44B5gGEpqEDRS9vVPz7QT35jcBG2r3CZwSwQ4fCewXAhAhqGVpP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract, err = single.NEP6Contract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract.Script != "21"+neoutils.BytesToHex(single.PublicKey)+"ac" || len(contract.Parameters) != 1 {
+		t.Fatalf("unexpected single signature contract %+v", contract)
+	}
+}
@@ -1,6 +1,8 @@
 package neoutils
 
 import (
+	"bytes"
+	"fmt"
 	"log"
 
 	"github.com/o3labs/neo-utils/neoutils/smartcontract"
@@ -9,10 +11,14 @@ import (
 type NativeAssetInterface interface {
 	SendNativeAssetRawTransaction(wallet Wallet, asset smartcontract.NativeAsset, amount float64, to smartcontract.NEOAddress, unspent smartcontract.Unspent, attributes map[smartcontract.TransactionAttribute][]byte) ([]byte, string, error)
 	GenerateRawTx(fromAddress string, asset smartcontract.NativeAsset, amount float64, to smartcontract.NEOAddress, unspent smartcontract.Unspent, attributes map[smartcontract.TransactionAttribute][]byte) ([]byte, string, error)
+	SendNativeAssetWithFeePayerRawTransaction(wallet Wallet, feePayer Wallet, asset smartcontract.NativeAsset, amount float64, to smartcontract.NEOAddress, unspent smartcontract.Unspent, feePayerUnspent smartcontract.Unspent, attributes map[smartcontract.TransactionAttribute][]byte) ([]byte, string, error)
+	ClaimAndSendNEORawTransactions(wallet Wallet, amount float64, to smartcontract.NEOAddress, unspent smartcontract.Unspent, claims []smartcontract.UTXO, claimAmount float64) ([]byte, string, []byte, string, error)
+	ConsolidateRawTransaction(wallet Wallet, asset smartcontract.NativeAsset, unspent smartcontract.Unspent, maxInputs int) ([]byte, string, error)
 }
 
 type NativeAsset struct {
 	NetworkFeeAmount smartcontract.NetworkFeeAmount //allow users to override the network fee here
+	MaxInputs        int                            //maximum number of UTXOs spent for each asset. 0 means no limit
 }
 
 func UseNativeAsset(networkFeeAmount smartcontract.NetworkFeeAmount) NativeAsset {
@@ -23,6 +29,12 @@ func UseNativeAsset(networkFeeAmount smartcontract.NetworkFeeAmount) NativeAsset
 
 var _ NativeAssetInterface = (*NativeAsset)(nil)
 
+//builder of the inputs and the outputs of a transaction. the outputs select the UTXOs again to compute the change
+//so both must be built with the same MaxInputs or the change won't match the inputs
+func transferScriptBuilder(maxInputs int) smartcontract.ScriptBuilderInterface {
+	return &smartcontract.ScriptBuilder{RawBytes: []byte{}, MaxInputs: maxInputs}
+}
+
 func (n *NativeAsset) SendNativeAssetRawTransaction(wallet Wallet, asset smartcontract.NativeAsset, amount float64, to smartcontract.NEOAddress, unspent smartcontract.Unspent, attributes map[smartcontract.TransactionAttribute][]byte) ([]byte, string, error) {
 	tx, txID, err := n.GenerateRawTx(wallet.Address, asset, amount, to, unspent, attributes)
 	if err != nil {
@@ -62,7 +74,7 @@ func (n *NativeAsset) GenerateRawTx(fromAddress string, asset smartcontract.Nati
 	tx := smartcontract.NewContractTransaction()
 
 	//generate transaction inputs
-	txInputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, asset, amount, n.NetworkFeeAmount)
+	txInputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionInput(unspent, asset, amount, n.NetworkFeeAmount)
 	if err != nil {
 		return nil, "", err
 	}
@@ -77,7 +89,7 @@ func (n *NativeAsset) GenerateRawTx(fromAddress string, asset smartcontract.Nati
 
 	sender := smartcontract.ParseNEOAddress(fromAddress)
 
-	txOutputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, to, unspent, asset, amount, n.NetworkFeeAmount)
+	txOutputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionOutput(sender, to, unspent, asset, amount, n.NetworkFeeAmount)
 	if err != nil {
 		log.Printf("%v", err)
 		return nil, "", err
@@ -85,5 +97,78 @@ func (n *NativeAsset) GenerateRawTx(fromAddress string, asset smartcontract.Nati
 
 	tx.Outputs = txOutputs
 
+	err = smartcontract.ValidateInputsCoverOutputs(unspent, txInputs, txOutputs, n.NetworkFeeAmount)
+	if err != nil {
+		return nil, "", err
+	}
+
 	return tx.ToBytes(), tx.ToTXID(), nil
 }
+
+//SendNativeAssetWithFeePayerRawTransaction sends the asset from wallet while feePayer supplies the GAS for the network fee.
+//both wallets spend inputs so the transaction carries two witnesses.
+//wallet and feePayer must be different accounts. use SendNativeAssetRawTransaction when the sender pays its own fee
+func (n *NativeAsset) SendNativeAssetWithFeePayerRawTransaction(wallet Wallet, feePayer Wallet, asset smartcontract.NativeAsset, amount float64, to smartcontract.NEOAddress, unspent smartcontract.Unspent, feePayerUnspent smartcontract.Unspent, attributes map[smartcontract.TransactionAttribute][]byte) ([]byte, string, error) {
+	//one script hash with two witnesses is rejected by the node
+	if wallet.Address == feePayer.Address {
+		return nil, "", fmt.Errorf("fee payer %v is the same as the sender", feePayer.Address)
+	}
+	tx := smartcontract.NewContractTransaction()
+
+	txInputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionInputWithFeePayer(unspent, asset, amount, feePayerUnspent, n.NetworkFeeAmount)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Inputs = txInputs
+
+	txAttributes, err := smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Attributes = txAttributes
+
+	sender := smartcontract.ParseNEOAddress(wallet.Address)
+	feePayerAddress := smartcontract.ParseNEOAddress(feePayer.Address)
+	txOutputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionOutputWithFeePayer(sender, to, unspent, asset, amount, feePayerAddress, feePayerUnspent, n.NetworkFeeAmount)
+	if err != nil {
+		return nil, "", err
+	}
+	tx.Outputs = txOutputs
+
+	unsignedTx := tx.ToBytes()
+	signatures := []interface{}{}
+	signers := []Wallet{wallet, feePayer}
+	//the node expects witnesses in the same order as the script hashes they verify.
+	//UInt160 compares from the last byte so the little endian hash is compared reversed
+	if bytes.Compare(ReverseBytes(smartcontract.ParseNEOAddress(wallet.Address)), ReverseBytes(smartcontract.ParseNEOAddress(feePayer.Address))) > 0 {
+		signers = []Wallet{feePayer, wallet}
+	}
+	for _, signer := range signers {
+		signedData, err := Sign(unsignedTx, bytesToHex(signer.PrivateKey))
+		if err != nil {
+			return nil, "", err
+		}
+		signatures = append(signatures, smartcontract.TransactionSignature{
+			SignedData: signedData,
+			PublicKey:  signer.PublicKey,
+		})
+	}
+	txScripts := smartcontract.NewScriptBuilder().GenerateVerificationScripts(signatures)
+
+	endPayload := []byte{}
+	endPayload = append(endPayload, unsignedTx...)
+	endPayload = append(endPayload, txScripts...)
+
+	return endPayload, tx.ToTXID(), nil
+}
+
+//SendAsset builds and signs a transaction sending the asset from the wallet without network fee.
+//it returns the raw transaction in hex ready for sendrawtransaction and the txid
+func (w *Wallet) SendAsset(unspent smartcontract.Unspent, asset smartcontract.NativeAsset, to smartcontract.NEOAddress, amount float64) (string, string, error) {
+	nativeAsset := UseNativeAsset(smartcontract.NetworkFeeAmount(0))
+	raw, txID, err := nativeAsset.SendNativeAssetRawTransaction(*w, asset, amount, to, unspent, nil)
+	if err != nil {
+		return "", "", err
+	}
+	return bytesToHex(raw), txID, nil
+}
@@ -210,3 +210,218 @@ func TestSendingGASFromMultiSig(t *testing.T) {
 	length := len(b)
 	log.Printf("%x%x%v", endPayload, length, redeemScript)
 }
+
+func TestSendNativeAssetWithFeePayer(t *testing.T) {
+	sender, _ := neoutils.NewWallet()
+	sponsor, _ := neoutils.NewWallet()
+	receiver, _ := neoutils.NewWallet()
+
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.NEO: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "0x2a6d2f1a1bc7b1cb1cfb4b2f2c1b6cbb3f2c4e9b0f5a1d7e6c8b9a0f1e2d3c4b", Value: 10},
+				},
+			},
+		},
+	}
+	sponsorUnspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 1, TXID: "0x6c4b3a2f1e0d9c8b7a695847362514f3e2d1c0b9a8f7e6d5c4b3a29180706050", Value: 1},
+				},
+			},
+		},
+	}
+
+	nativeAsset := neoutils.UseNativeAsset(smartcontract.NetworkFeeAmount(0.001))
+	to := smartcontract.ParseNEOAddress(receiver.Address)
+	raw, txID, err := nativeAsset.SendNativeAssetWithFeePayerRawTransaction(*sender, *sponsor, smartcontract.NEO, 5, to, unspent, sponsorUnspent, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Printf("%v %x", txID, raw)
+
+	//type + version + no attribute
+	inputsOffset := 3
+	if raw[inputsOffset] != 2 {
+		t.Fatalf("expected 2 inputs got %v", raw[inputsOffset])
+	}
+	outputsOffset := inputsOffset + 1 + 2*34
+	//NEO to receiver, NEO change to sender and GAS change to the sponsor
+	if raw[outputsOffset] != 3 {
+		t.Fatalf("expected 3 outputs got %v", raw[outputsOffset])
+	}
+	scriptsOffset := outputsOffset + 1 + 3*60
+	if raw[scriptsOffset] != 2 {
+		t.Fatalf("expected 2 witnesses got %v", raw[scriptsOffset])
+	}
+
+	unsignedTx := raw[:scriptsOffset]
+	hash := sha256.Sum256(unsignedTx)
+	witnessLength := 102
+	signers := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		witness := raw[scriptsOffset+1+i*witnessLength : scriptsOffset+1+(i+1)*witnessLength]
+		signature := witness[2:66]
+		publicKey := witness[68:101]
+		if neoutils.Verify(publicKey, signature, hash[:]) == false {
+			t.Fatalf("invalid signature for %x", publicKey)
+		}
+		signers[fmt.Sprintf("%x", publicKey)] = true
+	}
+	if signers[fmt.Sprintf("%x", sender.PublicKey)] == false || signers[fmt.Sprintf("%x", sponsor.PublicKey)] == false {
+		t.Fail()
+	}
+}
+
+func TestSendNativeAssetWithFeePayerSameWallet(t *testing.T) {
+	sender, _ := neoutils.NewWallet()
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.NEO: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "0x2a6d2f1a1bc7b1cb1cfb4b2f2c1b6cbb3f2c4e9b0f5a1d7e6c8b9a0f1e2d3c4b", Value: 10},
+				},
+			},
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 1, TXID: "0x6c4b3a2f1e0d9c8b7a695847362514f3e2d1c0b9a8f7e6d5c4b3a29180706050", Value: 1},
+				},
+			},
+		},
+	}
+	nativeAsset := neoutils.UseNativeAsset(smartcontract.NetworkFeeAmount(0.001))
+	to := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	_, _, err := nativeAsset.SendNativeAssetWithFeePayerRawTransaction(*sender, *sender, smartcontract.NEO, 5, to, unspent, unspent, nil)
+	if err == nil {
+		t.Fatal("expected an error when the fee payer is the sender")
+	}
+}
+
+func TestWalletSendAsset(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 10},
+				},
+			},
+		},
+	}
+	to := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	rawHex, txID, err := wallet.SendAsset(unspent, smartcontract.GAS, to, 1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Printf("%v %v", txID, rawHex)
+
+	expectedUnsigned := "8000" + //contract transaction version 0
+		"00" + //no attribute
+		"01" + "fe65fc0c69b6d8bea4c7ff2e3b158ae089f055e1af8567ab747a120ec70f641b" + "0000" + //input
+		"02" + //outputs
+		"e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c60" + "80d1f00800000000" + "5f8e3fcb095b55f53c44a1cab6e9c1a0da67cf87" +
+		"e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c60" + "80f8a93200000000" + fmt.Sprintf("%x", []byte(smartcontract.ParseNEOAddress(wallet.Address)))
+	if strings.HasPrefix(rawHex, expectedUnsigned) == false {
+		t.Fatalf("unexpected unsigned transaction %v", rawHex)
+	}
+
+	raw := neoutils.HexTobytes(rawHex)
+	unsigned := raw[:len(expectedUnsigned)/2]
+	witness := raw[len(unsigned):]
+	//1 witness. invocation script 0x41 0x40 [signature] then verification script 0x23 0x21 [public key] CHECKSIG
+	if len(witness) != 1+1+1+64+1+1+33+1 || witness[0] != 0x01 {
+		t.Fatalf("unexpected witness %x", witness)
+	}
+	hash := sha256.Sum256(unsigned)
+	if neoutils.Verify(wallet.PublicKey, witness[3:67], hash[:]) == false {
+		t.Fail()
+	}
+	if fmt.Sprintf("%x", witness[69:102]) != fmt.Sprintf("%x", wallet.PublicKey) {
+		t.Fail()
+	}
+}
+
+func TestSignedTransactionWitnessScripts(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 10},
+				},
+			},
+		},
+	}
+	to := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	rawHex, _, err := wallet.SendAsset(unspent, smartcontract.GAS, to, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := smartcontract.DeserializeTransaction(neoutils.HexTobytes(rawHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invocationScript, err := tx.InvocationScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verificationScript, err := tx.VerificationScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//PUSHBYTES64 [signature]
+	if len(invocationScript) != 65 || invocationScript[0] != 0x40 {
+		t.Fatalf("unexpected invocation script %x", invocationScript)
+	}
+	unsigned := tx.ToBytes()[:len(tx.ToBytes())-len(tx.Script)]
+	hash := sha256.Sum256(unsigned)
+	if neoutils.Verify(wallet.PublicKey, invocationScript[1:], hash[:]) == false {
+		t.Fail()
+	}
+	//PUSHBYTES33 [public key] CHECKSIG
+	expected := fmt.Sprintf("21%xac", wallet.PublicKey)
+	if fmt.Sprintf("%x", verificationScript) != expected {
+		t.Fatalf("expected %v got %x", expected, verificationScript)
+	}
+}
+
+func TestGenerateRawTxMaxInputs(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1},
+					{Index: 1, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1},
+					{Index: 2, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1},
+					{Index: 3, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 5},
+					{Index: 4, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 6},
+				},
+			},
+		},
+	}
+	//the smallest first needs all 5 UTXOs so the 2 largest are spent
+	nativeAsset := neoutils.NativeAsset{MaxInputs: 2}
+	to := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	raw, _, err := nativeAsset.GenerateRawTx(wallet.Address, smartcontract.GAS, 10, to, unspent, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "8000" + //contract transaction version 0
+		"00" + //no attribute
+		"02" + //the 6 and 5 GAS inputs
+		"fe65fc0c69b6d8bea4c7ff2e3b158ae089f055e1af8567ab747a120ec70f641b" + "0400" +
+		"fe65fc0c69b6d8bea4c7ff2e3b158ae089f055e1af8567ab747a120ec70f641b" + "0300" +
+		"02" + //10 GAS to the receiver and the change of 1 GAS from the same 2 inputs
+		"e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c60" + "00ca9a3b00000000" + "5f8e3fcb095b55f53c44a1cab6e9c1a0da67cf87" +
+		"e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c60" + "00e1f50500000000" + fmt.Sprintf("%x", []byte(smartcontract.ParseNEOAddress(wallet.Address)))
+	if fmt.Sprintf("%x", raw) != expected {
+		t.Fatalf("expected %v got %x", expected, raw)
+	}
+}
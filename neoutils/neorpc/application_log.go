@@ -0,0 +1,78 @@
+package neorpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//ErrInvocationReturnedFalse is returned when the invocation ran but the contract returned false e.g. a NEP-5 transfer without enough balance.
+//the transaction is still in a block and its fee is spent
+var ErrInvocationReturnedFalse = errors.New("invocation returned false")
+
+//GetApplicationLog gets the result of running an invocation transaction. the node needs the ApplicationLogs plugin
+func (n *NEORPCClient) GetApplicationLog(ctx context.Context, txID string) (GetApplicationLogResponse, error) {
+	response := GetApplicationLogResponse{}
+	err := n.makeRequestWithContext(ctx, "getapplicationlog", []interface{}{txID}, &response)
+	if err != nil {
+		return response, err
+	}
+	if response.ErrorResponse != nil {
+		return response, fmt.Errorf("%v", response.Error.Message)
+	}
+	return response, nil
+}
+
+//a stack item is true the same way the VM converts it to a boolean. any non zero byte is true
+func stackItemIsTrue(item StackItem) (bool, error) {
+	switch v := item.Value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch item.Type {
+		case "Boolean":
+			return v == "true" || v == "True", nil
+		case "Integer":
+			return v != "0" && v != "", nil
+		case "ByteArray":
+			return strings.Trim(v, "0") != "", nil
+		}
+	}
+	return false, fmt.Errorf("can't read %v %v as a boolean", item.Type, item.Value)
+}
+
+//CheckReturnedTrue checks that the Application execution of the log halted and left true on top of the stack
+//which is how a NEP-5 transfer says it succeeded
+func (r GetApplicationLogResponse) CheckReturnedTrue() error {
+	for _, execution := range r.Result.Executions {
+		if execution.Trigger != "" && execution.Trigger != "Application" {
+			continue
+		}
+		if strings.Contains(execution.VMState, "FAULT") {
+			return fmt.Errorf("invocation %v failed with vm state %v", r.Result.Txid, execution.VMState)
+		}
+		if len(execution.Stack) == 0 {
+			return fmt.Errorf("invocation %v returned nothing", r.Result.Txid)
+		}
+		returned, err := stackItemIsTrue(execution.Stack[len(execution.Stack)-1])
+		if err != nil {
+			return err
+		}
+		if returned == false {
+			return fmt.Errorf("%w. transaction %v", ErrInvocationReturnedFalse, r.Result.Txid)
+		}
+		return nil
+	}
+	return fmt.Errorf("no application execution in the log of %v", r.Result.Txid)
+}
+
+//ConfirmInvocationReturnedTrue gets the application log of a broadcast invocation and checks that the contract returned true.
+//call it once the transaction is in a block
+func (n *NEORPCClient) ConfirmInvocationReturnedTrue(ctx context.Context, txID string) error {
+	response, err := n.GetApplicationLog(ctx, txID)
+	if err != nil {
+		return err
+	}
+	return response.CheckReturnedTrue()
+}
@@ -0,0 +1,47 @@
+package neorpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/neorpc"
+)
+
+func TestConfirmInvocationReturnedTrue(t *testing.T) {
+	logs := map[string]string{
+		//transfer without enough balance returns false
+		"0x9e2e2c0f0e1c8ab2f1b1e8ac5cbb8ee4d6c8ce2d5b7e3ae4b1e5b4f1e0f8b3a1": `{"txid":"0x9e2e2c0f0e1c8ab2f1b1e8ac5cbb8ee4d6c8ce2d5b7e3ae4b1e5b4f1e0f8b3a1","executions":[{"trigger":"Application","contract":"0x3ba5a18a6d1a3e8b3ffb5f5e5dc2a9e8a5c4c1d0","vmstate":"HALT","gas_consumed":"1.319","stack":[{"type":"ByteArray","value":""}],"notifications":[]}]}`,
+		"0x1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe": `{"txid":"0x1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe","executions":[{"trigger":"Application","contract":"0x3ba5a18a6d1a3e8b3ffb5f5e5dc2a9e8a5c4c1d0","vmstate":"HALT","gas_consumed":"2.855","stack":[{"type":"Integer","value":"1"}],"notifications":[]}]}`,
+		"0xad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0": `{"txid":"0xad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0","executions":[{"trigger":"Application","contract":"0x3ba5a18a6d1a3e8b3ffb5f5e5dc2a9e8a5c4c1d0","vmstate":"FAULT, BREAK","gas_consumed":"0.1","stack":[],"notifications":[]}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := neorpc.JSONRPCRequest{}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Method != "getapplicationlog" || len(request.Params) != 1 {
+			t.Errorf("unexpected request %+v", request)
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":%v}`, logs[fmt.Sprintf("%v", request.Params[0])])
+	}))
+	defer server.Close()
+	client := neorpc.NewClient(server.URL)
+
+	err := client.ConfirmInvocationReturnedTrue(context.Background(), "0x9e2e2c0f0e1c8ab2f1b1e8ac5cbb8ee4d6c8ce2d5b7e3ae4b1e5b4f1e0f8b3a1")
+	if errors.Is(err, neorpc.ErrInvocationReturnedFalse) == false {
+		t.Fatalf("expected ErrInvocationReturnedFalse got %v", err)
+	}
+
+	err = client.ConfirmInvocationReturnedTrue(context.Background(), "0x1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.ConfirmInvocationReturnedTrue(context.Background(), "0xad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0")
+	if err == nil || errors.Is(err, neorpc.ErrInvocationReturnedFalse) {
+		t.Fatalf("expected a fault error got %v", err)
+	}
+}
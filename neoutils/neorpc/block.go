@@ -0,0 +1,32 @@
+package neorpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//GetDecodedBlock gets the raw block by index (int) or by hash (string) and deserializes it including every transaction
+func (n *NEORPCClient) GetDecodedBlock(ctx context.Context, indexOrHash interface{}) (*smartcontract.Block, error) {
+	switch indexOrHash.(type) {
+	case int, uint32, string:
+	default:
+		return nil, fmt.Errorf("block index must be int or uint32 and block hash must be string but got %T", indexOrHash)
+	}
+	response := GetRawBlockResponse{}
+	params := []interface{}{indexOrHash, 0}
+	err := n.makeRequestWithContext(ctx, "getblock", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.ErrorResponse != nil {
+		return nil, fmt.Errorf("%v", response.Error.Message)
+	}
+	b, err := hex.DecodeString(response.Result)
+	if err != nil {
+		return nil, err
+	}
+	return smartcontract.DeserializeBlock(b)
+}
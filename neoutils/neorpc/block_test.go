@@ -0,0 +1,116 @@
+package neorpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/neorpc"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//synthetic block with a miner transaction, a contract transaction and an invocation transaction.
+//it's not on any chain. signatures are dummy bytes and the consensus data is 0x1122334455667788
+const syntheticBlock = "000000007bfa6687b10aa275e4387dd157a9e7550bc1fa293690d24423d7bd0f700aa45b3e0d98a65d0263306d51330c2b15ebc5b6ebce9cece7f80c955d548b7bc9065dc046bb5ba8e2290088776655443322115f8e3fcb095b55f53c44a1cab6e9c1a0da67cf87014140000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f0151030000d8ef2f000000000080000001fe65fc0c69b6d8bea4c7ff2e3b158ae089f055e1af8567ab747a120ec70f641b000001e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c6080d1f008000000005f8e3fcb095b55f53c44a1cab6e9c1a0da67cf87014140000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f232102e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986acd1011c00c1046e616d65679b7cffdaa674beae0f930ebe6085af9093e5fe56000000000000000001205f8e3fcb095b55f53c44a1cab6e9c1a0da67cf870000014140000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f232102e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986ac"
+
+func TestGetDecodedBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := neorpc.JSONRPCRequest{}
+		json.NewDecoder(r.Body).Decode(&request)
+		//verbose 0 returns the block in hex
+		if request.Method != "getblock" || len(request.Params) != 2 || request.Params[0] != float64(2745000) || request.Params[1] != float64(0) {
+			t.Errorf("unexpected request %+v", request)
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"%v"}`, syntheticBlock)
+	}))
+	defer server.Close()
+
+	client := neorpc.NewClient(server.URL)
+	block, err := client.GetDecodedBlock(context.Background(), 2745000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Index != 2745000 || block.Timestamp != 1539000000 {
+		t.Fatalf("unexpected header %+v", block)
+	}
+	if block.Hash().String() != "3821461725c94a4c558eb4096c489e844be4b160f80381768f2f8a1978372d2a" {
+		t.Fatalf("unexpected block hash %v", block.Hash().String())
+	}
+	if block.PreviousBlockHash.String() != "5ba40a700fbdd72344d2903629fac10b55e7a957d17d38e475a20ab18766fa7b" {
+		t.Fatalf("unexpected previous block hash %v", block.PreviousBlockHash.String())
+	}
+
+	expected := []struct {
+		txType    smartcontract.TransactionType
+		txID      string
+		witnesses int
+	}{
+		{smartcontract.MinerTransaction, "b51f280f00cfbdec6dc69f30433874a589858c39039651d897327b51b4895cd7", 0},
+		{smartcontract.ContractTransaction, "99a61b51910efafac1345dabbb8a5bdef76d2012ab3780b836f6f7b85d814fba", 1},
+		{smartcontract.InvocationTransaction, "a113d46eabe993d72e85f84fd4457d99eaaea88f8e81b8e81d26c2208a3507e9", 1},
+	}
+	if len(block.Transactions) != len(expected) {
+		t.Fatalf("expected %v transactions got %v", len(expected), len(block.Transactions))
+	}
+	for i, e := range expected {
+		tx := block.Transactions[i]
+		witnesses, err := tx.Witnesses()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Type != e.txType || tx.ToTXID() != e.txID || len(witnesses) != e.witnesses {
+			t.Fatalf("transaction %v expected %v %v got %v %v", i, e.txType, e.txID, tx.Type, tx.ToTXID())
+		}
+	}
+}
+
+//MainNet genesis block from getblock 0 0. it has the RegisterTransaction of NEO and GAS
+const rawGenesisBlock = "000000000000000000000000000000000000000000000000000000000000000000000000f41bc036e39b0d6b0579c851c6fde83af802fa4e57bec0bc3365eae3abf43f8065fc8857000000001dac2b7c0000000059e75d652b5d3827bf04c165bbe9ef95cca4bf55010001510400001dac2b7c00000000400000455b7b226c616e67223a227a682d434e222c226e616d65223a22e5b08fe89a81e882a1227d2c7b226c616e67223a22656e222c226e616d65223a22416e745368617265227d5d0000c16ff28623000000da1745e9b549bd0bfa1a569971c77eba30cd5a4b00000000400001445b7b226c616e67223a227a682d434e222c226e616d65223a22e5b08fe89a81e5b881227d2c7b226c616e67223a22656e222c226e616d65223a22416e74436f696e227d5d0000c16ff286230008009f7fd096d37ed2c0e3f7f0cfc924beef4ffceb680000000001000000019b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc50000c16ff28623005fa99d93303775fe50ca119c327759313eccfa1c01000151"
+
+func TestGetDecodedGenesisBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"%v"}`, rawGenesisBlock)
+	}))
+	defer server.Close()
+
+	block, err := neorpc.NewClient(server.URL).GetDecodedBlock(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Hash().String() != "d42561e3d30e15be6400b6df2f328e02d2bf6354c41dce433bc57687c82144bf" {
+		t.Fatalf("unexpected block hash %v", block.Hash().String())
+	}
+	if block.Index != 0 || block.Timestamp != 1468595301 || block.ConsensusData != 2083236893 {
+		t.Fatalf("unexpected header %+v", block)
+	}
+
+	expected := []struct {
+		txType smartcontract.TransactionType
+		txID   string
+	}{
+		{smartcontract.MinerTransaction, "fb5bd72b2d6792d75dc2f1084ffa9e9f70ca85543c717a6b13d9959b452a57d6"},
+		{smartcontract.RegisterTransaction, string(smartcontract.NEO)},
+		{smartcontract.RegisterTransaction, string(smartcontract.GAS)},
+		{smartcontract.IssueTransaction, "3631f66024ca6f5b033d7e0809eb993443374830025af904fb51b0334f127cda"},
+	}
+	if len(block.Transactions) != len(expected) {
+		t.Fatalf("expected %v transactions got %v", len(expected), len(block.Transactions))
+	}
+	for i, e := range expected {
+		tx := block.Transactions[i]
+		if tx.Type != e.txType || tx.ToTXID() != e.txID {
+			t.Fatalf("transaction %v expected %v %v got %v %v", i, e.txType, e.txID, tx.Type, tx.ToTXID())
+		}
+	}
+}
+
+func TestGetDecodedBlockInvalidArgument(t *testing.T) {
+	client := neorpc.NewClient("http://localhost:30333")
+	_, err := client.GetDecodedBlock(context.Background(), 1.5)
+	if err == nil {
+		t.Fail()
+	}
+}
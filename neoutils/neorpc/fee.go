@@ -0,0 +1,42 @@
+package neorpc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//every invocation transaction can consume up to 10 GAS for free
+const freeGAS = float64(10)
+
+//SystemFeeFromGasConsumed returns the system fee an invocation transaction needs to attach for the gas_consumed of an invokescript dry run.
+//it follows what neo-cli does. the free GAS is subtracted and the rest is rounded up to a whole GAS
+func SystemFeeFromGasConsumed(gasConsumed string) (float64, error) {
+	consumed, err := strconv.ParseFloat(strings.TrimSpace(gasConsumed), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gas_consumed %v", gasConsumed)
+	}
+	fee := consumed - freeGAS
+	if fee <= 0 {
+		return 0, nil
+	}
+	return math.Ceil(fee), nil
+}
+
+//EstimateSystemFee runs the script with invokescript and returns the system fee required to run it on chain
+func (n *NEORPCClient) EstimateSystemFee(scriptInHex string) (float64, error) {
+	response := InvokeScriptResponse{}
+	params := []interface{}{scriptInHex}
+	err := n.makeRequest("invokescript", params, &response)
+	if err != nil {
+		return 0, err
+	}
+	if response.ErrorResponse != nil {
+		return 0, fmt.Errorf("%v", response.Error.Message)
+	}
+	if strings.Contains(response.Result.State, "FAULT") {
+		return 0, fmt.Errorf("script execution failed with state %v", response.Result.State)
+	}
+	return SystemFeeFromGasConsumed(response.Result.GasConsumed)
+}
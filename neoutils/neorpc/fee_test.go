@@ -0,0 +1,50 @@
+package neorpc_test
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/neorpc"
+)
+
+func TestSystemFeeFromGasConsumed(t *testing.T) {
+	cases := map[string]float64{
+		"0.126":   0,
+		"10":      0,
+		"10.001":  1,
+		"12.5":    3,
+		"1009.99": 1000,
+	}
+	for gasConsumed, expected := range cases {
+		fee, err := neorpc.SystemFeeFromGasConsumed(gasConsumed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fee != expected {
+			t.Fatalf("gas_consumed %v expected %v got %v", gasConsumed, expected, fee)
+		}
+	}
+}
+
+func TestEstimateSystemFee(t *testing.T) {
+	//made up invokescript result with a gas_consumed over the 10 free GAS
+	gasConsumed := 490.034
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"script":"00c1046e616d6567f8e679d19048360e414c82d82fdb33486438d37c","state":"HALT, BREAK","gas_consumed":"%v","stack":[{"type":"ByteArray","value":"4e454f"}]}}`, gasConsumed)
+	}))
+	defer server.Close()
+
+	client := neorpc.NewClient(server.URL)
+	fee, err := client.EstimateSystemFee("00c1046e616d6567f8e679d19048360e414c82d82fdb33486438d37c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//whatever is over the free 10 GAS rounded up to a whole GAS
+	expected := math.Ceil(gasConsumed - 10)
+	if fee != expected {
+		t.Fatalf("expected %v got %v", expected, fee)
+	}
+}
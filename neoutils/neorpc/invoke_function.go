@@ -0,0 +1,25 @@
+package neorpc
+
+import (
+	"fmt"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//InvokeFunction dry runs operation of the contract with structured parameters instead of a raw script
+//the result is the same as InvokeScript
+func (n *NEORPCClient) InvokeFunction(scriptHash smartcontract.ScriptHash, operation string, args []smartcontract.ContractParameter) (InvokeScriptResponse, error) {
+	response := InvokeScriptResponse{}
+	params, err := smartcontract.InvokeFunctionParams(scriptHash, operation, args)
+	if err != nil {
+		return response, err
+	}
+	err = n.makeRequest("invokefunction", params, &response)
+	if err != nil {
+		return response, err
+	}
+	if response.ErrorResponse != nil {
+		return response, fmt.Errorf("%v", response.Error.Message)
+	}
+	return response, nil
+}
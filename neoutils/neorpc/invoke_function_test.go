@@ -0,0 +1,42 @@
+package neorpc_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/neorpc"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestInvokeFunctionBalanceOf(t *testing.T) {
+	//the contract and the address script hash are in big endian
+	expected := `["ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9","balanceOf",[{"type":"Hash160","value":"87cf67daa0c1e9b6caa1443cf5555b09cb3f8e5f"}]]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Method != "invokefunction" || string(request.Params) != expected {
+			t.Errorf("expected %v got %v %s", expected, request.Method, request.Params)
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"script":"","state":"HALT, BREAK","gas_consumed":"0.338","stack":[{"type":"ByteArray","value":"00e1f505"}]}}`)
+	}))
+	defer server.Close()
+
+	scriptHash, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	address := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	args := []smartcontract.ContractParameter{{Type: smartcontract.Hash160Type, Value: address}}
+
+	client := neorpc.NewClient(server.URL)
+	response, err := client.InvokeFunction(scriptHash, "balanceOf", args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Result.State != "HALT, BREAK" || response.Result.Stack[0].Value != "00e1f505" {
+		t.Fatalf("unexpected response %+v", response.Result)
+	}
+}
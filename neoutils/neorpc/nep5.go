@@ -0,0 +1,126 @@
+package neorpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//TokenInfo is the metadata of a NEP-5 token
+type TokenInfo struct {
+	ScriptHash  smartcontract.ScriptHash
+	Name        string
+	Symbol      string
+	Decimals    int
+	TotalSupply *big.Int //in the smallest unit. divide by 10^Decimals for the amount
+}
+
+func (n *NEORPCClient) invokeScriptWithContext(ctx context.Context, script []byte) (InvokeScriptResponse, error) {
+	response := InvokeScriptResponse{}
+	params := []interface{}{hex.EncodeToString(script), 1}
+	err := n.makeRequestWithContext(ctx, "invokescript", params, &response)
+	if err != nil {
+		return response, err
+	}
+	if response.ErrorResponse != nil {
+		return response, fmt.Errorf("%v", response.Error.Message)
+	}
+	if strings.Contains(response.Result.State, "FAULT") {
+		return response, fmt.Errorf("invocation failed with vm state %v", response.Result.State)
+	}
+	if len(response.Result.Stack) == 0 {
+		return response, fmt.Errorf("invocation returned nothing")
+	}
+	return response, nil
+}
+
+//strings like name and symbol are returned as a ByteArray of the utf8 bytes in hex
+func stackItemToString(itemType string, value string) (string, error) {
+	switch itemType {
+	case "String":
+		return value, nil
+	case "ByteArray":
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return "", fmt.Errorf("can't read %v %v as a string", itemType, value)
+}
+
+//integers are returned as an Integer in decimal or a ByteArray of the little endian two's complement in hex
+func stackItemToInteger(itemType string, value string) (*big.Int, error) {
+	switch itemType {
+	case "Integer":
+		v, ok := new(big.Int).SetString(value, 10)
+		if ok == false {
+			return nil, fmt.Errorf("invalid integer %v", value)
+		}
+		return v, nil
+	case "ByteArray":
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		bigEndian := make([]byte, len(b))
+		for i := range b {
+			bigEndian[len(b)-1-i] = b[i]
+		}
+		v := new(big.Int).SetBytes(bigEndian)
+		if len(b) > 0 && b[len(b)-1]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("can't read %v %v as an integer", itemType, value)
+}
+
+//GetNEP5TokenInfo gets the name, symbol, decimals and total supply of a NEP-5 token with one invokescript call for each
+func (n *NEORPCClient) GetNEP5TokenInfo(ctx context.Context, tokenScriptHash smartcontract.ScriptHash) (TokenInfo, error) {
+	info := TokenInfo{ScriptHash: tokenScriptHash}
+	results := map[string]InvokeScriptResponse{}
+	for _, operation := range []string{"symbol", "decimals", "name", "totalSupply"} {
+		//PUSH0 PACK for the empty args array the NEP-5 entry point expects then the operation and APPCALL
+		script, err := smartcontract.NewScriptBuilder().GenerateContractInvocationScriptWithError(tokenScriptHash, operation, []interface{}{})
+		if err != nil {
+			return info, fmt.Errorf("%v: %v", operation, err)
+		}
+		response, err := n.invokeScriptWithContext(ctx, script)
+		if err != nil {
+			return info, fmt.Errorf("%v: %v", operation, err)
+		}
+		results[operation] = response
+	}
+
+	var err error
+	top := func(operation string) (string, string) {
+		stack := results[operation].Result.Stack
+		return stack[len(stack)-1].Type, stack[len(stack)-1].Value
+	}
+	info.Symbol, err = stackItemToString(top("symbol"))
+	if err != nil {
+		return info, fmt.Errorf("symbol: %v", err)
+	}
+	info.Name, err = stackItemToString(top("name"))
+	if err != nil {
+		return info, fmt.Errorf("name: %v", err)
+	}
+	decimals, err := stackItemToInteger(top("decimals"))
+	if err != nil {
+		return info, fmt.Errorf("decimals: %v", err)
+	}
+	if decimals.Sign() < 0 || decimals.Cmp(big.NewInt(255)) > 0 {
+		return info, fmt.Errorf("invalid decimals %v", decimals)
+	}
+	info.Decimals = int(decimals.Int64())
+	info.TotalSupply, err = stackItemToInteger(top("totalSupply"))
+	if err != nil {
+		return info, fmt.Errorf("totalSupply: %v", err)
+	}
+	return info, nil
+}
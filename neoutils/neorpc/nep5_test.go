@@ -0,0 +1,53 @@
+package neorpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/neorpc"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestGetNEP5TokenInfo(t *testing.T) {
+	//invokescript results of each call to the RPX contract keyed by the exact script the client must send
+	results := map[string]string{
+		"00c10673796d626f6c67f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec":           `{"script":"00c10673796d626f6c67f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec","state":"HALT, BREAK","gas_consumed":"0.126","stack":[{"type":"ByteArray","value":"525058"}]}`,
+		"00c108646563696d616c7367f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec":       `{"script":"00c108646563696d616c7367f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec","state":"HALT, BREAK","gas_consumed":"0.118","stack":[{"type":"Integer","value":"8"}]}`,
+		"00c1046e616d6567f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec":               `{"script":"00c1046e616d6567f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec","state":"HALT, BREAK","gas_consumed":"0.126","stack":[{"type":"ByteArray","value":"5265642050756c736520546f6b656e"}]}`,
+		"00c10b746f74616c537570706c7967f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec": `{"script":"00c10b746f74616c537570706c7967f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec","state":"HALT, BREAK","gas_consumed":"0.257","stack":[{"type":"ByteArray","value":"0072ef3e2597e201"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := neorpc.JSONRPCRequest{}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Method != "invokescript" {
+			t.Errorf("unexpected method %v", request.Method)
+		}
+		script := fmt.Sprintf("%v", request.Params[0])
+		result, ok := results[script]
+		if ok == false {
+			t.Errorf("unexpected script %v", script)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":%v}`, result)
+	}))
+	defer server.Close()
+
+	scriptHash, err := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := neorpc.NewClient(server.URL).GetNEP5TokenInfo(context.Background(), scriptHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name != "Red Pulse Token" || info.Symbol != "RPX" || info.Decimals != 8 {
+		t.Fatalf("unexpected token info %+v", info)
+	}
+	if info.TotalSupply.String() != "135837125000000000" {
+		t.Fatalf("expected total supply 135837125000000000 got %v", info.TotalSupply)
+	}
+}
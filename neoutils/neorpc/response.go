@@ -95,6 +95,13 @@ type GetBlockResponse struct {
 	Result         GetBlockResult `json:"result"`
 }
 
+//result of getblock with verbose 0. the block in hex
+type GetRawBlockResponse struct {
+	JSONRPCResponse
+	*ErrorResponse        //optional
+	Result         string `json:"result"`
+}
+
 type GetBlockResult struct {
 	Hash              string `json:"hash"`
 	Size              int    `json:"size"`
@@ -180,3 +187,28 @@ type InvokeScriptResponse struct {
 		} `json:"stack"`
 	} `json:"result"`
 }
+
+//StackItem is an item left on the VM stack. value is a string for most types, a bool for Boolean and a list for Array
+type StackItem struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type ApplicationExecution struct {
+	Trigger     string      `json:"trigger"`
+	Contract    string      `json:"contract"`
+	VMState     string      `json:"vmstate"`
+	GasConsumed string      `json:"gas_consumed"`
+	Stack       []StackItem `json:"stack"`
+}
+
+type GetApplicationLogResult struct {
+	Txid       string                 `json:"txid"`
+	Executions []ApplicationExecution `json:"executions"`
+}
+
+type GetApplicationLogResponse struct {
+	JSONRPCResponse
+	*ErrorResponse                         //optional
+	Result         GetApplicationLogResult `json:"result"`
+}
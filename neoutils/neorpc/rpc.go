@@ -2,6 +2,7 @@ package neorpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/o3labs/neo-utils/neoutils/btckey"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
 )
 
 type NEORPCInterface interface {
@@ -25,34 +27,99 @@ type NEORPCInterface interface {
 }
 
 type NEORPCClient struct {
-	Endpoint   url.URL
-	httpClient *http.Client
+	Endpoint    url.URL
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+}
+
+//RetryPolicy retries a request when the node can't be reached or responds with HTTP 5xx.
+//errors returned by the node in JSON-RPC response (e.g. already exists) are never retried.
+type RetryPolicy struct {
+	MaxAttempts int           //total number of attempts including the first one
+	BaseDelay   time.Duration //delay before the second attempt. it doubles after each attempt
 }
 
 //make sure all method interface is implemented
 var _ NEORPCInterface = (*NEORPCClient)(nil)
 
 func NewClient(endpoint string) *NEORPCClient {
+	return NewClientWithHTTPClient(endpoint, nil)
+}
+
+//NewClientWithHTTPClient uses httpClient for every request e.g. one with a proxy or custom TLS config.
+//nil uses a client with a 60 seconds timeout
+func NewClientWithHTTPClient(endpoint string, httpClient *http.Client) *NEORPCClient {
 	u, err := url.Parse(endpoint)
 	if err != nil {
 		return nil
 	}
-	// var netTransport = &http.Transport{
-	// 	Dial: (&net.Dialer{
-	// 		Timeout: 8 * time.Second,
-	// 	}).Dial,
-	// 	TLSHandshakeTimeout: 8 * time.Second,
-	// }
+	if httpClient == nil {
+		// var netTransport = &http.Transport{
+		// 	Dial: (&net.Dialer{
+		// 		Timeout: 8 * time.Second,
+		// 	}).Dial,
+		// 	TLSHandshakeTimeout: 8 * time.Second,
+		// }
+
+		httpClient = &http.Client{
+			Timeout: time.Second * 60,
+			// Transport: netTransport,
+		}
+	}
 
-	var netClient = &http.Client{
-		Timeout: time.Second * 60,
-		// Transport: netTransport,
+	return &NEORPCClient{Endpoint: *u, httpClient: httpClient}
+}
+
+func NewClientWithRetryPolicy(endpoint string, retryPolicy RetryPolicy) *NEORPCClient {
+	return NewClientWithHTTPClientAndRetryPolicy(endpoint, nil, retryPolicy)
+}
+
+//NewClientWithHTTPClientAndRetryPolicy is NewClientWithHTTPClient that also retries like NewClientWithRetryPolicy
+//e.g. a client behind a proxy talking to a node that sometimes fails. nil httpClient uses the default client
+func NewClientWithHTTPClientAndRetryPolicy(endpoint string, httpClient *http.Client, retryPolicy RetryPolicy) *NEORPCClient {
+	client := NewClientWithHTTPClient(endpoint, httpClient)
+	if client == nil {
+		return nil
 	}
+	client.retryPolicy = &retryPolicy
+	return client
+}
 
-	return &NEORPCClient{Endpoint: *u, httpClient: netClient}
+//error that is worth trying the request again
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
 }
 
 func (n *NEORPCClient) makeRequest(method string, params []interface{}, out interface{}) error {
+	return n.makeRequestWithContext(context.Background(), method, params, out)
+}
+
+func (n *NEORPCClient) makeRequestWithContext(ctx context.Context, method string, params []interface{}, out interface{}) error {
+	if n.retryPolicy == nil {
+		return n.makeRequestOnce(ctx, method, params, out)
+	}
+
+	delay := n.retryPolicy.BaseDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = n.makeRequestOnce(ctx, method, params, out)
+		if _, retryable := err.(retryableError); !retryable || attempt >= n.retryPolicy.MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (n *NEORPCClient) makeRequestOnce(ctx context.Context, method string, params []interface{}, out interface{}) error {
 	request := NewRequest(method, params)
 
 	jsonValue, _ := json.Marshal(request)
@@ -60,14 +127,18 @@ func (n *NEORPCClient) makeRequest(method string, params []interface{}, out inte
 	if err != nil {
 		return err
 	}
+	req = req.WithContext(ctx)
 	req.Header.Add("content-type", "application/json")
 	req.Header.Set("Connection", "close")
 	req.Close = true
 	res, err := n.httpClient.Do(req)
 	if err != nil {
-		return err
+		return retryableError{err}
 	}
 	defer res.Body.Close()
+	if res.StatusCode >= http.StatusInternalServerError {
+		return retryableError{fmt.Errorf("node responded with status %v", res.StatusCode)}
+	}
 	err = json.NewDecoder(res.Body).Decode(&out)
 	if err != nil {
 		return err
@@ -151,7 +222,7 @@ func (n *NEORPCClient) GetTokenBalance(tokenHash string, neoAddress string) Toke
 	args := []interface{}{}
 
 	v, b, _ := btckey.B58checkdecode(neoAddress)
-	if v != 0x17 {
+	if v != smartcontract.AddressVersion {
 		return TokenBalanceResponse{}
 	}
 	adddressScriptHash := fmt.Sprintf("%x", b)
@@ -1,8 +1,12 @@
 package neorpc_test
 
 import (
+	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/o3labs/neo-utils/neoutils/neorpc"
 )
@@ -106,3 +110,94 @@ func TestInvokeScript(t *testing.T) {
 	result := client.InvokeScript(script)
 	log.Printf("%+v", result.Result)
 }
+
+func TestRetryPolicy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts += 1
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":2345678}`)
+	}))
+	defer server.Close()
+
+	client := neorpc.NewClientWithRetryPolicy(server.URL, neorpc.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	result := client.GetBlockCount()
+	log.Printf("%+v", result)
+	if attempts != 3 || result.Result != 2345678 {
+		t.Fail()
+	}
+}
+
+func TestRetryPolicyDoesNotRetryRPCError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts += 1
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-501,"message":"Block or transaction already exists and cannot be sent repeatedly."}}`)
+	}))
+	defer server.Close()
+
+	client := neorpc.NewClientWithRetryPolicy(server.URL, neorpc.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	result := client.SendRawTransaction("00")
+	if attempts != 1 || result.ErrorResponse == nil || result.Error.Code != -501 {
+		t.Fail()
+	}
+}
+
+type countingTransport struct {
+	count int
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":100}`)
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client := neorpc.NewClientWithHTTPClient(server.URL, &http.Client{Transport: transport, Timeout: 5 * time.Second})
+	response := client.GetBlockCount()
+	if response.Result != 100 {
+		t.Fatalf("expected 100 got %v", response.Result)
+	}
+	if transport.count != 1 {
+		t.Fatalf("expected the request to go through the custom transport got %v requests", transport.count)
+	}
+
+	//nil is the default client
+	if neorpc.NewClientWithHTTPClient(server.URL, nil).GetBlockCount().Result != 100 {
+		t.Fatal("expected the default client to work")
+	}
+}
+
+func TestNewClientWithHTTPClientAndRetryPolicy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts += 1
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":100}`)
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	httpClient := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+	client := neorpc.NewClientWithHTTPClientAndRetryPolicy(server.URL, httpClient, neorpc.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	response := client.GetBlockCount()
+	if response.Result != 100 {
+		t.Fatalf("expected 100 got %v", response.Result)
+	}
+	//every attempt goes through the custom transport
+	if attempts != 3 || transport.count != 3 {
+		t.Fatalf("expected 3 attempts through the custom transport got %v and %v", attempts, transport.count)
+	}
+}
@@ -0,0 +1,129 @@
+package neoscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//https://github.com/CityOfZion/neo-scan
+
+var netClient = &http.Client{
+	Timeout: time.Second * 60,
+}
+
+func getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Add("content-type", "application/json")
+	res, err := netClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("neoscan returned status %v", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+//GetUnspentsFromNeoScan fetches the balance of the address from neoscan's get_balance endpoint
+//and maps it to smartcontract.Unspent
+func GetUnspentsFromNeoScan(ctx context.Context, baseURL string, address string) (smartcontract.Unspent, error) {
+	endpoint := fmt.Sprintf("%v/v1/get_balance/%v", strings.TrimRight(baseURL, "/"), address)
+	response := BalanceResponse{}
+	err := getJSON(ctx, endpoint, &response)
+	if err != nil {
+		return smartcontract.Unspent{}, err
+	}
+	return UnspentFromBalanceResponse(response), nil
+}
+
+//UnspentFromBalanceResponse maps neoscan's balance format to smartcontract.Unspent
+//only native assets have unspent so NEP-5 tokens are skipped
+func UnspentFromBalanceResponse(response BalanceResponse) smartcontract.Unspent {
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{},
+	}
+	for _, v := range response.Balance {
+		assetHash := strings.TrimPrefix(v.AssetHash, "0x")
+		asset, ok := smartcontract.NativeAssets[assetHash]
+		if !ok {
+			continue
+		}
+		balance := smartcontract.Balance{
+			Amount: v.Amount,
+			UTXOs:  []smartcontract.UTXO{},
+		}
+		for _, u := range v.Unspent {
+			balance.UTXOs = append(balance.UTXOs, smartcontract.UTXO{
+				Index: u.N,
+				TXID:  u.TXID,
+				Value: u.Value,
+			})
+		}
+		unspent.Assets[asset] = &balance
+	}
+	return unspent
+}
+
+//GetClaimableFromNeoScan fetches the spent NEO outputs of the address that have GAS to claim from neoscan's get_claimable endpoint
+func GetClaimableFromNeoScan(ctx context.Context, baseURL string, address string) (ClaimableResponse, error) {
+	endpoint := fmt.Sprintf("%v/v1/get_claimable/%v", strings.TrimRight(baseURL, "/"), address)
+	response := ClaimableResponse{}
+	err := getJSON(ctx, endpoint, &response)
+	if err != nil {
+		return ClaimableResponse{}, err
+	}
+	return response, nil
+}
+
+//ClaimsFromClaimableResponse returns the outputs to claim and the sum of their unclaimed GAS.
+//the sum is added up in fixed8 and not taken from the unclaimed field of the response so it always matches the claims
+func ClaimsFromClaimableResponse(response ClaimableResponse) ([]smartcontract.UTXO, float64) {
+	claims := []smartcontract.UTXO{}
+	total := smartcontract.Fixed8(0)
+	for _, c := range response.Claimable {
+		claims = append(claims, smartcontract.UTXO{
+			Index: c.N,
+			TXID:  c.TXID,
+			Value: c.Value,
+		})
+		total = total.Add(smartcontract.NewFixed8(c.Unclaimed))
+	}
+	return claims, total.Float64()
+}
+
+//ClaimTransactionFromClaimableResponse builds the unsigned claim transaction of a get_claimable response.
+//the claimed GAS goes to the address of the response. sign it and append the witness before sending it
+func ClaimTransactionFromClaimableResponse(response ClaimableResponse) (*smartcontract.Transaction, error) {
+	receiver := smartcontract.ParseNEOAddress(response.Address)
+	if receiver == nil {
+		return nil, fmt.Errorf("invalid address %v", response.Address)
+	}
+	claims, amount := ClaimsFromClaimableResponse(response)
+
+	tx := smartcontract.NewClaimTransaction()
+	txData, err := smartcontract.NewScriptBuilder().GenerateClaimTransactionData(claims)
+	if err != nil {
+		return nil, err
+	}
+	tx.Data = txData
+	tx.Attributes = smartcontract.NewScriptBuilder().EmptyTransactionAttributes()
+	//a claim transaction doesn't spend anything
+	tx.Inputs = []byte{0x00}
+	txOutputs, err := smartcontract.NewScriptBuilder().GenerateClaimTransactionOutput(receiver, amount)
+	if err != nil {
+		return nil, err
+	}
+	tx.Outputs = txOutputs
+	return &tx, nil
+}
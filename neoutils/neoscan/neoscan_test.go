@@ -0,0 +1,104 @@
+package neoscan_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/neoscan"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//made up in the shape of the neoscan v1 get_balance response. the txids are not real transactions
+const syntheticBalanceResponse = `{"unclaimed":0.00103716,"balance":[{"unspent":[{"value":0.5,"txid":"4ebd0b2fee2b2dcc36dc8a8ccd5d9f3fb3e0e3d4aa7a9d9fbc3d8d8e25a1a0f8","n":0},{"value":1.25,"txid":"0x5f6b8c6a3b9d1e8f2a4c7d0e3b6a9c2f5e8d1b4a7c0f3e6d9b2a5c8f1e4d7b0a","n":1}],"asset_symbol":"GAS","asset_hash":"602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7","asset":"GAS","amount":1.75},{"unspent":[{"value":10,"txid":"a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90","n":0}],"asset_symbol":"NEO","asset_hash":"c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b","asset":"NEO","amount":10},{"unspent":[],"asset_symbol":"RPX","asset_hash":"ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9","asset":"Red Pulse Token","amount":100}],"address":"AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y"}`
+
+func TestGetUnspentsFromNeoScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/get_balance/AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, syntheticBalanceResponse)
+	}))
+	defer server.Close()
+
+	unspent, err := neoscan.GetUnspentsFromNeoScan(context.Background(), server.URL, "AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	log.Printf("%+v", unspent)
+
+	if len(unspent.Assets) != 2 {
+		t.Fail()
+		return
+	}
+	gas := unspent.Assets[smartcontract.GAS]
+	if gas == nil || len(gas.UTXOs) != 2 || gas.TotalAmount() != 1.75 || gas.UTXOs[1].Index != 1 {
+		t.Fail()
+		return
+	}
+	neo := unspent.Assets[smartcontract.NEO]
+	if neo == nil || len(neo.UTXOs) != 1 || neo.Amount != 10 {
+		t.Fail()
+	}
+}
+
+func TestGetUnspentsFromNeoScanCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, syntheticBalanceResponse)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := neoscan.GetUnspentsFromNeoScan(ctx, server.URL, "AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")
+	if err == nil {
+		t.Fail()
+	}
+}
+
+//made up in the shape of the neoscan v1 get_claimable response. the first txid is not a real transaction
+const syntheticClaimableResponse = `{"unclaimed":0.000705,"claimable":[{"value":10,"unclaimed":0.00057,"txid":"9e2e2c0f0e1c8ab2f1b1e8ac5cbb8ee4d6c8ce2d5b7e3ae4b1e5b4f1e0f8b3a1","sys_fee":0.00001,"start_height":2745000,"n":1,"generated":0.00056,"end_height":2745070},{"value":5,"unclaimed":0.000135,"txid":"1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe","sys_fee":0,"start_height":2745010,"n":0,"generated":0.000135,"end_height":2745037}],"address":"AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y"}`
+
+func TestClaimTransactionFromNeoScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/get_claimable/AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, syntheticClaimableResponse)
+	}))
+	defer server.Close()
+
+	response, err := neoscan.GetClaimableFromNeoScan(context.Background(), server.URL, "AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, amount := neoscan.ClaimsFromClaimableResponse(response)
+	if len(claims) != 2 || claims[0].Index != 1 || amount != 0.000705 {
+		t.Fatalf("unexpected claims %+v of %v", claims, amount)
+	}
+
+	tx, err := neoscan.ClaimTransactionFromClaimableResponse(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type != smartcontract.ClaimTransaction {
+		t.Fatalf("unexpected type %v", tx.Type)
+	}
+	//2 claims [txid in little endian][index]. each txid of the response reversed
+	expectedData := "02" + "a1b3f8e0f1b4e5b1e43a7e5b2dcec8d6e48ebb5cace8b1f1b28a1c0e0f2c2e9e" + "0100" + "fe65fc0c69b6d8bea4c7ff2e3b158ae089f055e1af8567ab747a120ec70f641b" + "0000"
+	if fmt.Sprintf("%x", tx.Data) != expectedData {
+		t.Fatalf("expected claims %v got %x", expectedData, tx.Data)
+	}
+	//one GAS output of 0.000705 to the address
+	expectedOutputs := "01" + "e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c60" + "6413010000000000" + fmt.Sprintf("%x", []byte(smartcontract.ParseNEOAddress("AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")))
+	if fmt.Sprintf("%x", tx.Attributes) != "00" || fmt.Sprintf("%x", tx.Inputs) != "00" || fmt.Sprintf("%x", tx.Outputs) != expectedOutputs {
+		t.Fatalf("unexpected attributes %x inputs %x or outputs %x", tx.Attributes, tx.Inputs, tx.Outputs)
+	}
+}
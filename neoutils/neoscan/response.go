@@ -0,0 +1,39 @@
+package neoscan
+
+type BalanceResponse struct {
+	Unclaimed float64        `json:"unclaimed"`
+	Balance   []AssetBalance `json:"balance"`
+	Address   string         `json:"address"`
+}
+
+type AssetBalance struct {
+	Unspent     []Unspent `json:"unspent"`
+	AssetSymbol string    `json:"asset_symbol"`
+	AssetHash   string    `json:"asset_hash"`
+	Asset       string    `json:"asset"`
+	Amount      float64   `json:"amount"`
+}
+
+type Unspent struct {
+	Value float64 `json:"value"`
+	TXID  string  `json:"txid"`
+	N     int     `json:"n"`
+}
+
+//ClaimableResponse is the response of get_claimable. each claimable is a spent NEO output with the GAS it generated
+type ClaimableResponse struct {
+	Unclaimed float64     `json:"unclaimed"`
+	Claimable []Claimable `json:"claimable"`
+	Address   string      `json:"address"`
+}
+
+type Claimable struct {
+	Value       float64 `json:"value"`
+	Unclaimed   float64 `json:"unclaimed"`
+	TXID        string  `json:"txid"`
+	N           int     `json:"n"`
+	StartHeight int     `json:"start_height"`
+	EndHeight   int     `json:"end_height"`
+	Generated   float64 `json:"generated"`
+	SysFee      float64 `json:"sys_fee"`
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/o3labs/neo-utils/neoutils/btckey"
+	"github.com/o3labs/neo-utils/neoutils/nep6"
 
 	"github.com/o3labs/neo-utils/neoutils/sss"
 )
@@ -28,6 +29,11 @@ func bytesToHex(b []byte) string {
 	return hex.EncodeToString(b)
 }
 
+//ValidatePrivateKey checks that the raw private key is 32 bytes and in the valid range of the curve
+func ValidatePrivateKey(b []byte) error {
+	return btckey.ValidatePrivateKey(b)
+}
+
 // Generate a wallet from a private key
 func GenerateFromPrivateKey(privateKey string) (*Wallet, error) {
 	pb := hex2bytes(privateKey)
@@ -82,6 +88,14 @@ func NewWallet() (*Wallet, error) {
 	return wallet, nil
 }
 
+//NEP6Contract returns the contract object to save the single signature account of the wallet in a NEP-6 wallet
+func (w *Wallet) NEP6Contract() (nep6.NEP6Contract, error) {
+	//PUSHBYTES33 [public key] CHECKSIG
+	script := append([]byte{0x21}, w.PublicKey...)
+	script = append(script, 0xAC)
+	return nep6.NewNEP6Contract(script)
+}
+
 //Shared Secret with 2 parts.
 type SharedSecret struct {
 	First  []byte
@@ -141,3 +155,30 @@ func Sign(data []byte, key string) ([]byte, error) {
 func Verify(publicKey []byte, signature []byte, hash []byte) bool {
 	return btckey.Verify(publicKey, signature, hash)
 }
+
+//DetachedSignature is a signature of a transaction without the witness around it.
+//a cosigning service returns it and whoever collects them assembles the witness
+type DetachedSignature struct {
+	Signature []byte //64 bytes r+s
+	PublicKey []byte //compressed public key
+}
+
+//SignDetached signs the unsigned transaction with the private key and returns only the signature and the public key
+func SignDetached(unsignedTransaction []byte, privateKey []byte) (*DetachedSignature, error) {
+	if len(unsignedTransaction) == 0 {
+		return nil, errors.New("Invalid transaction")
+	}
+	var priv btckey.PrivateKey
+	err := priv.FromBytes(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := Sign(unsignedTransaction, bytesToHex(priv.ToBytes()))
+	if err != nil {
+		return nil, err
+	}
+	return &DetachedSignature{
+		Signature: signature,
+		PublicKey: priv.PublicKey.ToBytes(),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package neoutils_test
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"log"
@@ -93,3 +94,23 @@ func TestRecoverFromString(t *testing.T) {
 	}
 	fmt.Printf("%v", recovered)
 }
+
+func TestSignDetached(t *testing.T) {
+	wallet, _ := neoutils.GenerateFromWIF("L4sSGSGh15dtocMMSYS115fhZEVN9UuETWDjgGKu2JDu59yncyVf")
+	unsignedTransaction := neoutils.HexTobytes("80000001e72d286979ee6cb1b7e65dfddfb2e384100b8d148e7758de42e4168b71792c6001000288a7a0e0fa9f9d7a25c3bc0d3c0ba5e2dcc6fd4af3d3d78c1ec48b0e38d9f9100e1f50500000000e60d8dc74cfeb0de4a0ca6a4cdc8f1c59e1b0b2e9b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc500e1f50500000000e60d8dc74cfeb0de4a0ca6a4cdc8f1c59e1b0b2e")
+
+	detached, err := neoutils.SignDetached(unsignedTransaction, wallet.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(detached.Signature) != 64 {
+		t.Fatalf("expected 64 bytes signature got %v", len(detached.Signature))
+	}
+	if hex.EncodeToString(detached.PublicKey) != hex.EncodeToString(wallet.PublicKey) {
+		t.Fatalf("expected public key %x got %x", wallet.PublicKey, detached.PublicKey)
+	}
+	hash := sha256.Sum256(unsignedTransaction)
+	if neoutils.Verify(detached.PublicKey, detached.Signature, hash[:]) == false {
+		t.Fatal("detached signature doesn't verify against the transaction hash")
+	}
+}
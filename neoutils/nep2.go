@@ -1,6 +1,10 @@
 package neoutils
 
-import "github.com/o3labs/neo-utils/neoutils/nep2"
+import (
+	"fmt"
+
+	"github.com/o3labs/neo-utils/neoutils/nep2"
+)
 
 type NEP2 struct {
 	EncryptedKey string
@@ -18,3 +22,30 @@ func NEP2Encrypt(wif string, passphrase string) (*NEP2, error) {
 func NEP2Decrypt(key, passphrase string) (s string, err error) {
 	return nep2.NEP2Decrypt(key, passphrase)
 }
+
+//WIFToNEP2 encrypts a WIF to a NEP-2 key with the passphrase
+func WIFToNEP2(wif string, passphrase string) (string, error) {
+	encryptedKey, _, err := nep2.NEP2Encrypt(wif, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return encryptedKey, nil
+}
+
+//NEP2ToWIF decrypts a NEP-2 key to a WIF. it returns an error when the passphrase is wrong
+func NEP2ToWIF(nep2Key string, passphrase string) (string, error) {
+	wif, err := nep2.NEP2Decrypt(nep2Key, passphrase)
+	if err != nil {
+		return "", err
+	}
+	if wif == "" {
+		return "", fmt.Errorf("invalid NEP-2 key")
+	}
+	return wif, nil
+}
+
+//NEP2DecryptWithScryptParams decrypts a NEP-2 key from a wallet that doesn't use the standard scrypt parameters
+//e.g. the scrypt object of a NEP-6 wallet
+func NEP2DecryptWithScryptParams(key string, passphrase string, n int, r int, p int) (string, error) {
+	return nep2.NEP2Decrypt(key, passphrase, nep2.ScryptParams{N: n, R: r, P: p})
+}
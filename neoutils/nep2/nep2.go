@@ -28,23 +28,40 @@ const (
 
 var nepHeader = []byte{0x01, 0x42}
 
-type scryptParams struct {
+//ScryptParams are the scrypt parameters used to derive the key from the passphrase
+type ScryptParams struct {
 	N int `json:"n"`
 	R int `json:"r"`
 	P int `json:"p"`
 }
 
-func newScryptParams() scryptParams {
-	return scryptParams{
+//DefaultScryptParams returns the parameters of the NEP-2 standard. 16384, 8, 8
+func DefaultScryptParams() ScryptParams {
+	return ScryptParams{
 		N: n,
 		R: r,
 		P: p,
 	}
 }
 
+//the parameters given to NEP2Encrypt or NEP2Decrypt or the standard ones when there is none
+func scryptParamsOrDefault(params []ScryptParams) (ScryptParams, error) {
+	if len(params) == 0 {
+		return DefaultScryptParams(), nil
+	}
+	if len(params) > 1 {
+		return ScryptParams{}, fmt.Errorf("expecting one set of scrypt parameters got %d", len(params))
+	}
+	return params[0], nil
+}
+
 // NEP2Encrypt encrypts a the PrivateKey using a given passphrase
-// under the NEP-2 standard.
-func NEP2Encrypt(wif string, passphrase string) (s string, address string, err error) {
+// under the NEP-2 standard. params are optional and default to the NEP-2 scrypt parameters.
+func NEP2Encrypt(wif string, passphrase string, params ...ScryptParams) (s string, address string, err error) {
+	scryptParams, err := scryptParamsOrDefault(params)
+	if err != nil {
+		return "", "", err
+	}
 	var privateKey btckey.PrivateKey
 	errFromWIF := privateKey.FromWIF(wif)
 	if err != nil {
@@ -56,7 +73,7 @@ func NEP2Encrypt(wif string, passphrase string) (s string, address string, err e
 
 	// Normalize the passphrase according to the NFC standard.
 	phraseNorm := norm.NFC.Bytes([]byte(passphrase))
-	derivedKey, err := scrypt.Key(phraseNorm, addressHash, n, r, p, keyLen)
+	derivedKey, err := scrypt.Key(phraseNorm, addressHash, scryptParams.N, scryptParams.R, scryptParams.P, keyLen)
 	if err != nil {
 		return s, "", err
 	}
@@ -84,11 +101,16 @@ func NEP2Encrypt(wif string, passphrase string) (s string, address string, err e
 }
 
 // NEP2Decrypt decrypts an encrypted key using a given passphrase
-// under the NEP-2 standard.
-func NEP2Decrypt(key, passphrase string) (s string, err error) {
+// under the NEP-2 standard. params must be the scrypt parameters the key was encrypted with
+// and default to the NEP-2 ones.
+func NEP2Decrypt(key, passphrase string, params ...ScryptParams) (s string, err error) {
+	scryptParams, err := scryptParamsOrDefault(params)
+	if err != nil {
+		return s, err
+	}
 	encrypted, err := crypto.Base58CheckDecode(key)
 	if err != nil {
-		return s, nil
+		return s, err
 	}
 	if err := validateNEP2Format(encrypted); err != nil {
 		return s, err
@@ -98,7 +120,7 @@ func NEP2Decrypt(key, passphrase string) (s string, err error) {
 
 	// Normalize the passphrase according to the NFC standard.
 	phraseNorm := norm.NFC.Bytes([]byte(passphrase))
-	derivedKey, err := scrypt.Key(phraseNorm, addrHash, n, r, p, keyLen)
+	derivedKey, err := scrypt.Key(phraseNorm, addrHash, scryptParams.N, scryptParams.R, scryptParams.P, keyLen)
 	if err != nil {
 		return s, err
 	}
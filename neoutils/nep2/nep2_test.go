@@ -39,3 +39,33 @@ func TestNEP2(t *testing.T) {
 	}
 	log.Printf("decrypted = %v", decrypted)
 }
+
+func TestNEP2DecryptWithScryptParams(t *testing.T) {
+	passphase := "TestingOneTwoThree"
+	WIF := "L44B5gGEpqEDRS9vVPz7QT35jcBG2r3CZwSwQ4fCewXAhAhqGVpP"
+	//encrypted with N=256, r=1, p=1 the way some light wallets do it
+	encrypted := "6PYVPVe1fsfC3eBK6SuKKSG9A7zCciHjjQaiRYZh9G5y2jMMMFwnBV6bvj"
+	params := nep2.ScryptParams{N: 256, R: 1, P: 1}
+
+	decrypted, err := nep2.NEP2Decrypt(encrypted, passphase, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted != WIF {
+		t.Fatalf("expected %v got %v", WIF, decrypted)
+	}
+
+	again, _, err := nep2.NEP2Encrypt(WIF, passphase, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != encrypted {
+		t.Fatalf("expected %v got %v", encrypted, again)
+	}
+
+	//the standard parameters derive a different key
+	_, err = nep2.NEP2Decrypt(encrypted, passphase)
+	if err == nil {
+		t.Fatal("expected an error decrypting with the standard scrypt parameters")
+	}
+}
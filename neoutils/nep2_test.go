@@ -0,0 +1,38 @@
+package neoutils_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils"
+)
+
+func TestWIFToNEP2(t *testing.T) {
+	wif := "L44B5gGEpqEDRS9vVPz7QT35jcBG2r3CZwSwQ4fCewXAhAhqGVpP"
+	passphrase := "TestingOneTwoThree"
+	expected := "6PYVPVe1fQznphjbUxXP9KZJqPMVnVwCx5s5pr5axRJ8uHkMtZg97eT5kL"
+
+	encrypted, err := neoutils.WIFToNEP2(wif, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encrypted != expected {
+		t.Fatalf("expected %v got %v", expected, encrypted)
+	}
+
+	decrypted, err := neoutils.NEP2ToWIF(encrypted, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted != wif {
+		t.Fatalf("expected %v got %v", wif, decrypted)
+	}
+
+	_, err = neoutils.NEP2ToWIF(encrypted, "wrong passphrase")
+	if err == nil {
+		t.Fatal("expected an error for a wrong passphrase")
+	}
+	_, err = neoutils.NEP2ToWIF("not a nep2 key", passphrase)
+	if err == nil {
+		t.Fatal("expected an error for an invalid key")
+	}
+}
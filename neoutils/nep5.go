@@ -16,6 +16,7 @@ type NEP5Interface interface {
 type NEP5 struct {
 	ScriptHash       smartcontract.ScriptHash
 	NetworkFeeAmount smartcontract.NetworkFeeAmount //allow users to override the network fee here
+	MaxInputs        int                            //maximum number of UTXOs spent for each asset. 0 means no limit
 }
 
 func UseNEP5WithNetworkFee(scriptHashHex string, networkFeeAmount smartcontract.NetworkFeeAmount) *NEP5 {
@@ -55,7 +56,10 @@ func (n *NEP5) TransferNEP5RawTransaction(wallet Wallet, toAddress smartcontract
 
 	//New invocation transaction struct and fill with all necessary data
 	tx := smartcontract.NewInvocationTransaction()
-	txData := smartcontract.NewScriptBuilder().GenerateContractInvocationData(n.ScriptHash, "transfer", args)
+	txData, err := smartcontract.NewScriptBuilder().GenerateContractInvocationDataWithError(n.ScriptHash, "transfer", args)
+	if err != nil {
+		return nil, "", err
+	}
 
 	tx.Data = txData
 
@@ -65,7 +69,7 @@ func (n *NEP5) TransferNEP5RawTransaction(wallet Wallet, toAddress smartcontract
 	assetToSend := smartcontract.GAS
 
 	//generate transaction inputs
-	txInputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
+	txInputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionInput(unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
 	if err != nil {
 		return nil, "", err
 	}
@@ -74,6 +78,7 @@ func (n *NEP5) TransferNEP5RawTransaction(wallet Wallet, toAddress smartcontract
 	tx.Inputs = txInputs
 
 	//generate transaction outputs
+	attributes = withSenderScriptAttribute(attributes, wallet, txInputs)
 	txAttributes, err := smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
 	if err != nil {
 		return nil, "", err
@@ -84,7 +89,7 @@ func (n *NEP5) TransferNEP5RawTransaction(wallet Wallet, toAddress smartcontract
 	//send GAS to the same account
 	sender := smartcontract.ParseNEOAddress(wallet.Address)
 	receiver := smartcontract.ParseNEOAddress(wallet.Address)
-	txOutputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
+	txOutputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
 	if err != nil {
 		return nil, "", err
 	}
@@ -134,13 +139,16 @@ func (n *NEP5) MintTokensRawTransaction(wallet Wallet, assetToSend smartcontract
 
 	//New invocation transaction struct and fill with all necessary data
 	tx := smartcontract.NewInvocationTransaction()
-	txData := smartcontract.NewScriptBuilder().GenerateContractInvocationData(n.ScriptHash, operation, args)
+	txData, err := smartcontract.NewScriptBuilder().GenerateContractInvocationDataWithError(n.ScriptHash, operation, args)
+	if err != nil {
+		return nil, "", err
+	}
 	tx.Data = txData
 
 	amountToSend := amount
 
 	//generate transaction inputs
-	txInputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
+	txInputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionInput(unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
 	if err != nil {
 		return nil, "", err
 	}
@@ -160,7 +168,7 @@ func (n *NEP5) MintTokensRawTransaction(wallet Wallet, assetToSend smartcontract
 	//when invoke the smart contract with amount of asset to send
 	//we simply set the receiver to be the smart contract address
 	receiver := smartcontract.NEOAddressFromScriptHash(n.ScriptHash.ToBigEndian())
-	txOutputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
+	txOutputs, err := transferScriptBuilder(n.MaxInputs).GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, n.NetworkFeeAmount)
 	if err != nil {
 		return nil, "", err
 	}
@@ -1,5 +1,12 @@
 package nep6
 
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
 // https://github.com/neo-project/proposals/blob/master/nep-6.mediawiki
 
 type NEP6Contract struct {
@@ -11,6 +18,33 @@ type NEP6Contract struct {
 	Deployed bool `json:"deployed,omitempty"`
 }
 
+//NEP6Parameter is a parameter of the contract function as described in NEP-3
+type NEP6Parameter struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+//NewNEP6Contract returns the contract object of a standard single signature or multisig verification script.
+//the parameters are one Signature for each signature the script needs, named parameter0, parameter1... the same as neo-cli
+func NewNEP6Contract(verificationScript []byte) (NEP6Contract, error) {
+	kind, m, _, _, err := smartcontract.ClassifyVerificationScript(verificationScript)
+	if err != nil {
+		return NEP6Contract{}, err
+	}
+	if kind != smartcontract.VerificationScriptSingleSig && kind != smartcontract.VerificationScriptMultiSig {
+		return NEP6Contract{}, fmt.Errorf("verification script is not a single signature or multisig script")
+	}
+	parameters := []interface{}{}
+	for i := 0; i < m; i++ {
+		parameters = append(parameters, NEP6Parameter{Name: fmt.Sprintf("parameter%d", i), Type: "Signature"})
+	}
+	return NEP6Contract{
+		Script:     hex.EncodeToString(verificationScript),
+		Parameters: parameters,
+		Deployed:   false,
+	}, nil
+}
+
 type NEP6Account struct {
 	// address is the base58 encoded address of the account.
 	Address string `json:"address"`
@@ -0,0 +1,138 @@
+package neoutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//ParameterContext is the JSON that neo-cli exports for a transaction that is waiting for signatures.
+//https://github.com/neo-project/neo/blob/master/neo/SmartContract/ContractParametersContext.cs
+type ParameterContext struct {
+	Type  string                           `json:"type"`
+	Hex   string                           `json:"hex"` //unsigned transaction
+	Items map[string]*ParameterContextItem `json:"items"`
+}
+
+//ParameterContextItem is keyed by the script hash of the verification script in the items object
+type ParameterContextItem struct {
+	Script     string                      `json:"script"`
+	Parameters []ParameterContextParameter `json:"parameters"`
+	Signatures map[string]string           `json:"signatures,omitempty"` //public key in hex -> signature in hex
+}
+
+type ParameterContextParameter struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func ParseParameterContext(jsonString string) (*ParameterContext, error) {
+	context := ParameterContext{}
+	err := json.Unmarshal([]byte(jsonString), &context)
+	if err != nil {
+		return nil, err
+	}
+	if len(context.Hex) == 0 {
+		return nil, fmt.Errorf("Parameter context doesn't contain a transaction")
+	}
+	return &context, nil
+}
+
+func (c *ParameterContext) UnsignedTransaction() []byte {
+	return HexTobytes(c.Hex)
+}
+
+//Sign signs the transaction with the wallet and adds the signature to every item the wallet's public key is part of.
+//items that already have all their parameters are skipped
+func (c *ParameterContext) Sign(wallet Wallet) error {
+	unsignedTransaction := c.UnsignedTransaction()
+	if len(unsignedTransaction) == 0 {
+		return fmt.Errorf("Invalid transaction hex")
+	}
+	signedData, err := Sign(unsignedTransaction, bytesToHex(wallet.PrivateKey))
+	if err != nil {
+		return err
+	}
+
+	signed := false
+	complete := false
+	for _, item := range c.Items {
+		kind, m, _, publicKeys, err := smartcontract.ClassifyVerificationScript(HexTobytes(item.Script))
+		if err != nil {
+			continue
+		}
+		index := -1
+		for i, pb := range publicKeys {
+			if bytes.Equal(pb, wallet.PublicKey) {
+				index = i
+			}
+		}
+		if index == -1 {
+			continue
+		}
+		//an item with every parameter filled is left as it is the same as neo-cli does
+		if item.isComplete() {
+			complete = true
+			continue
+		}
+
+		if kind == smartcontract.VerificationScriptSingleSig {
+			item.Parameters = []ParameterContextParameter{{Type: "Signature", Value: bytesToHex(signedData)}}
+			signed = true
+			continue
+		}
+
+		//multisig collects signatures keyed by public key until there are enough of them.
+		//then they're moved to parameters from the last public key in the script to the first like neo-cli's ContextItem
+		//because the parameters are pushed in reverse when the invocation script is built
+		if item.Signatures == nil {
+			item.Signatures = map[string]string{}
+		}
+		item.Signatures[bytesToHex(wallet.PublicKey)] = bytesToHex(signedData)
+		signed = true
+		if len(item.Signatures) < m {
+			continue
+		}
+		parameters := []ParameterContextParameter{}
+		for i := len(publicKeys) - 1; i >= 0 && len(parameters) < m; i-- {
+			signature, exist := item.Signatures[bytesToHex(publicKeys[i])]
+			if exist == false {
+				continue
+			}
+			parameters = append(parameters, ParameterContextParameter{Type: "Signature", Value: signature})
+		}
+		item.Parameters = parameters
+		item.Signatures = nil
+	}
+
+	if signed == false && complete == true {
+		return nil
+	}
+	if signed == false {
+		return fmt.Errorf("public key %x is not required to sign this transaction", wallet.PublicKey)
+	}
+	return nil
+}
+
+func (item *ParameterContextItem) isComplete() bool {
+	if len(item.Parameters) == 0 {
+		return false
+	}
+	for _, parameter := range item.Parameters {
+		if parameter.Value == nil {
+			return false
+		}
+	}
+	return true
+}
+
+//ToJSON re-emits the context so it can be imported back to neo-cli
+func (c *ParameterContext) ToJSON() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,125 @@
+package neoutils_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils"
+)
+
+const unsignedContractTransaction = "80000001195876cb34364dc38b730077156c6bc3a7fc570044a66fbfeeea56f71327e8ab0000029b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc500c65eaf440000000f9a23e06f74cf86b8827a9108ec2e0f89ad956c9b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc50092e14b5e00000030aab52ad93f6ce17ca07fa88fc191828c58cb71"
+
+func TestParameterContextSingleSig(t *testing.T) {
+	wallet, _ := neoutils.NewWallet()
+	script := fmt.Sprintf("21%xac", wallet.PublicKey)
+	scriptHash := neoutils.NEOAddressToScriptHashWithEndian(wallet.Address, binary.BigEndian)
+
+	context := fmt.Sprintf(`{"type":"Neo.Network.P2P.Payloads.ContractTransaction","hex":"%v","items":{"0x%v":{"script":"%v","parameters":[{"type":"Signature"}]}}}`, unsignedContractTransaction, scriptHash, script)
+
+	parsed, err := neoutils.ParseParameterContext(context)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	err = parsed.Sign(*wallet)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	out, err := parsed.ToJSON()
+	if err != nil {
+		t.Fail()
+		return
+	}
+	log.Printf("%v", out)
+
+	//read it back and verify the signature
+	reparsed, _ := neoutils.ParseParameterContext(out)
+	for _, item := range reparsed.Items {
+		signature := neoutils.HexTobytes(item.Parameters[0].Value.(string))
+		hash := sha256.Sum256(reparsed.UnsignedTransaction())
+		if neoutils.Verify(wallet.PublicKey, signature, hash[:]) == false {
+			t.Fail()
+		}
+	}
+}
+
+func TestParameterContextMultiSig(t *testing.T) {
+	wallet1, _ := neoutils.NewWallet()
+	wallet2, _ := neoutils.NewWallet()
+	multisigWallet, _ := neoutils.NewMultiSigWallet(2, [][]byte{wallet1.PublicKey, wallet2.PublicKey})
+
+	context := fmt.Sprintf(`{"type":"Neo.Network.P2P.Payloads.ContractTransaction","hex":"%v","items":{"0x00":{"script":"%x","parameters":[{"type":"Signature"},{"type":"Signature"}]}}}`, unsignedContractTransaction, multisigWallet.RedeemScript)
+	parsed, err := neoutils.ParseParameterContext(context)
+	if err != nil {
+		t.Fail()
+		return
+	}
+
+	parsed.Sign(*wallet1)
+	for _, item := range parsed.Items {
+		if len(item.Signatures) != 1 || item.Parameters[0].Value != nil {
+			t.Fail()
+			return
+		}
+	}
+
+	parsed.Sign(*wallet2)
+	for _, item := range parsed.Items {
+		if item.Signatures != nil || item.Parameters[0].Value == nil || item.Parameters[1].Value == nil {
+			t.Fail()
+			return
+		}
+	}
+	out, _ := parsed.ToJSON()
+	log.Printf("%v", out)
+
+	stranger, _ := neoutils.NewWallet()
+	if parsed.Sign(*stranger) == nil {
+		t.Fail()
+	}
+}
+
+func TestParameterContextNeoCLIFormat(t *testing.T) {
+	wallet1, _ := neoutils.GenerateFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+	wallet2, _ := neoutils.GenerateFromWIF("L44B5gGEpqEDRS9vVPz7QT35jcBG2r3CZwSwQ4fCewXAhAhqGVpP")
+	//2 of 2 multisig AQTn1hQLcAijs8ZWEURrk3zTBkhMfU5x3o of both wallets in the format neo-cli exports it
+	//after the owner of 026241e7... signed. the signature is kept in signatures keyed by public key
+	script := "5221026241e7e26b38bb7154b8ad49458b97fb1c4797443dc921c5ca5774f511a2bbfc210398b8d209365a197311d1b288424eaea556f6235f5730598dede5647f6a11d99a52ae"
+	signature1 := "fd59008a4605f5af100328bdc433cf5a3318fdd4a645154ecc887062657edccd083fcba50bbe9e723ee3eaf9e86f6e81d299d165515cb90be1186b84a2e409b8"
+	signature2 := "dc3dcefb4d4fe4c30102801fe11b8bef2e08a0c848a37613d782b3ad74de244d5fa29d1c1c6b40ec714d58e567ea6aa0de9a656fcbcf1a6554846d0bb4fd26b6"
+	exported := `{"type":"Neo.Network.P2P.Payloads.ContractTransaction","hex":"` + unsignedContractTransaction + `","items":{"0xf60ee03d30d3236520ca1d51936f95fcf8f04c5f":{"script":"` + script + `","parameters":[{"type":"Signature"},{"type":"Signature"}],"signatures":{"026241e7e26b38bb7154b8ad49458b97fb1c4797443dc921c5ca5774f511a2bbfc":"` + signature2 + `"}}}}`
+
+	context, err := neoutils.ParseParameterContext(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = context.Sign(*wallet1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the last public key of the script first the same as neo-cli. signatures is dropped once the parameters are filled
+	expected := `{"type":"Neo.Network.P2P.Payloads.ContractTransaction","hex":"` + unsignedContractTransaction + `","items":{"0xf60ee03d30d3236520ca1d51936f95fcf8f04c5f":{"script":"` + script + `","parameters":[{"type":"Signature","value":"` + signature1 + `"},{"type":"Signature","value":"` + signature2 + `"}]}}}`
+	out, err := context.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != expected {
+		t.Fatalf("expected %v got %v", expected, out)
+	}
+
+	//signing a complete item again leaves it as it is
+	err = context.Sign(*wallet2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ = context.ToJSON()
+	if out != expected {
+		t.Fatalf("expected a complete item to be left as it is got %v", out)
+	}
+}
@@ -3,6 +3,7 @@ package neoutils
 import (
 	"strings"
 
+	"github.com/o3labs/neo-utils/neoutils/neorpc"
 	"github.com/o3labs/neo-utils/neoutils/smartcontract"
 )
 
@@ -12,11 +13,15 @@ type SmartContractInterface interface {
 	//however, I want to keep them separated
 	GenerateInvokeFunctionRawTransaction(wallet Wallet, unspent smartcontract.Unspent, attributes map[smartcontract.TransactionAttribute][]byte, operation string, args []interface{}) ([]byte, error)
 	GenerateInvokeFunctionRawTransactionWithAmountToSend(wallet Wallet, asset smartcontract.NativeAsset, amount float64, unspent smartcontract.Unspent, attributes map[smartcontract.TransactionAttribute][]byte, operation string, args []interface{}) ([]byte, error)
+
+	//dry run the invocation on the node and returns the system fee it needs
+	EstimateSystemFee(client *neorpc.NEORPCClient, operation string, args []interface{}) (float64, error)
 }
 
 type SmartContract struct {
 	ScriptHash       smartcontract.ScriptHash
 	NetworkFeeAmount smartcontract.NetworkFeeAmount //allow users to override the network fee here
+	MaxInputs        int                            //maximum number of UTXOs spent for each asset. 0 means no limit
 }
 
 func UseSmartContractWithNetworkFee(scriptHashHex string, feeAmount smartcontract.NetworkFeeAmount) SmartContractInterface {
@@ -47,7 +52,10 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransaction(wallet Wallet, unsp
 
 	//New invocation transaction struct and fill with all necessary data
 	tx := smartcontract.NewInvocationTransaction()
-	txData := smartcontract.NewScriptBuilder().GenerateContractInvocationData(s.ScriptHash, operation, args)
+	txData, err := smartcontract.NewScriptBuilder().GenerateContractInvocationDataWithError(s.ScriptHash, operation, args)
+	if err != nil {
+		return nil, err
+	}
 	tx.Data = txData
 
 	//for smart contract invocation we send the minimum amount of gas to it
@@ -56,7 +64,7 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransaction(wallet Wallet, unsp
 	assetToSend := smartcontract.GAS
 
 	//generate transaction inputs
-	txInputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
+	txInputs, err := transferScriptBuilder(s.MaxInputs).GenerateTransactionInput(unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +72,7 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransaction(wallet Wallet, unsp
 	tx.Inputs = txInputs
 
 	//generate transaction outputs
+	attributes = withSenderScriptAttribute(attributes, wallet, txInputs)
 	txAttributes, err := smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
 	if err != nil {
 		return nil, err
@@ -74,7 +83,7 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransaction(wallet Wallet, unsp
 	//send GAS to the same account
 	sender := smartcontract.ParseNEOAddress(wallet.Address)
 	receiver := smartcontract.ParseNEOAddress(wallet.Address)
-	txOutputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
+	txOutputs, err := transferScriptBuilder(s.MaxInputs).GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -112,14 +121,17 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransactionWithAmountToSend(wal
 
 	//New invocation transaction struct and fill with all necessary data
 	tx := smartcontract.NewInvocationTransaction()
-	txData := smartcontract.NewScriptBuilder().GenerateContractInvocationData(s.ScriptHash, operation, args)
+	txData, err := smartcontract.NewScriptBuilder().GenerateContractInvocationDataWithError(s.ScriptHash, operation, args)
+	if err != nil {
+		return nil, err
+	}
 	tx.Data = txData
 
 	amountToSend := amount
 	assetToSend := asset
 
 	//generate transaction inputs
-	txInputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
+	txInputs, err := transferScriptBuilder(s.MaxInputs).GenerateTransactionInput(unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +139,7 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransactionWithAmountToSend(wal
 	tx.Inputs = txInputs
 
 	//generate transaction outputs
+	attributes = withSenderScriptAttribute(attributes, wallet, txInputs)
 	txAttributes, err := smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
 	if err != nil {
 		return nil, err
@@ -137,7 +150,7 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransactionWithAmountToSend(wal
 	//send GAS to the same account
 	sender := smartcontract.ParseNEOAddress(wallet.Address)
 	receiver := smartcontract.NEOAddressFromScriptHash(s.ScriptHash.ToBigEndian())
-	txOutputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
+	txOutputs, err := transferScriptBuilder(s.MaxInputs).GenerateTransactionOutput(sender, receiver, unspent, assetToSend, amountToSend, s.NetworkFeeAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -170,3 +183,35 @@ func (s *SmartContract) GenerateInvokeFunctionRawTransactionWithAmountToSend(wal
 
 	return endPayload, nil
 }
+
+func (s *SmartContract) EstimateSystemFee(client *neorpc.NEORPCClient, operation string, args []interface{}) (float64, error) {
+	script, err := smartcontract.NewScriptBuilder().GenerateContractInvocationScriptWithError(s.ScriptHash, operation, args)
+	if err != nil {
+		return 0, err
+	}
+	return client.EstimateSystemFee(bytesToHex(script))
+}
+
+//the node only checks the witness of an account that owns one of the inputs or is listed in a Script attribute.
+//inputs are always spent from the sender's unspent so the sender owns them whenever there is at least one input.
+//a pure invocation without inputs needs the sender script hash in a Script attribute to get its signature verified.
+//attributes given by the caller are copied and never modified
+func withSenderScriptAttribute(attributes map[smartcontract.TransactionAttribute][]byte, wallet Wallet, txInputs []byte) map[smartcontract.TransactionAttribute][]byte {
+	hasInputs := len(txInputs) > 0 && txInputs[0] != 0x00
+	if hasInputs == true {
+		return attributes
+	}
+	if _, exist := attributes[smartcontract.Script]; exist == true {
+		return attributes
+	}
+	sender := smartcontract.ParseNEOAddress(wallet.Address)
+	if sender == nil {
+		return attributes
+	}
+	withScript := map[smartcontract.TransactionAttribute][]byte{}
+	for k, v := range attributes {
+		withScript[k] = v
+	}
+	withScript[smartcontract.Script] = []byte(sender)
+	return withScript
+}
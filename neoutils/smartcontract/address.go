@@ -0,0 +1,71 @@
+package smartcontract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/o3labs/neo-utils/neoutils/btckey"
+)
+
+const (
+	//a NEO address is always 34 characters
+	neoAddressLength = 34
+	base58Alphabet   = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+//the first character is the same for every address of a version. A for 0x17 and N for 0x35
+//computed once for every version because AddressVersion can be changed at any time
+var addressPrefixes = func() [256]byte {
+	prefixes := [256]byte{}
+	for version := 0; version < 256; version++ {
+		prefixes[version] = btckey.B58checkencodeNEO(byte(version), make([]byte, Uint160Length))[0]
+	}
+	return prefixes
+}()
+
+//IsValidAddressFormat checks the length, the prefix and the character set of the address without decoding it.
+//use it to filter user input cheaply. it doesn't check the checksum
+func IsValidAddressFormat(s string) bool {
+	if len(s) != neoAddressLength || s[0] != addressPrefixes[AddressVersion] {
+		return false
+	}
+	for _, c := range s {
+		if strings.ContainsRune(base58Alphabet, c) == false {
+			return false
+		}
+	}
+	return true
+}
+
+//Base58 leaves out 0, O, I and l because they look alike. a mistyped address usually has one of them
+//so it's rejected with the position of the character before a decode turns it into a checksum failure
+func validateBase58Characters(s string) error {
+	for i, c := range s {
+		if strings.ContainsRune(base58Alphabet, c) == false {
+			return fmt.Errorf("invalid character '%c' at position %v", c, i)
+		}
+	}
+	return nil
+}
+
+//VerifyAddressChecksum fully decodes the address and returns an error when the format, version or checksum is invalid
+func VerifyAddressChecksum(s string) error {
+	if len(s) != neoAddressLength {
+		return fmt.Errorf("invalid NEO address length %v", len(s))
+	}
+	err := validateBase58Characters(s)
+	if err != nil {
+		return err
+	}
+	v, b, err := btckey.B58checkdecode(s)
+	if err != nil {
+		return err
+	}
+	if v != AddressVersion {
+		return fmt.Errorf("invalid NEO address version 0x%02x", v)
+	}
+	if len(b) != Uint160Length {
+		return fmt.Errorf("invalid NEO address length %v", len(b))
+	}
+	return nil
+}
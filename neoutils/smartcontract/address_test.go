@@ -0,0 +1,124 @@
+package smartcontract_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/btckey"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestIsValidAddressFormat(t *testing.T) {
+	if smartcontract.IsValidAddressFormat("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE") == false {
+		t.Fail()
+	}
+	//0 is not in the Base58 alphabet
+	if smartcontract.IsValidAddressFormat("AQV8F0Ni2o7EtMNn4etWBYx1cqBREAifgE") == true {
+		t.Fail()
+	}
+	if smartcontract.IsValidAddressFormat("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifg") == true {
+		t.Fail()
+	}
+}
+
+func TestVerifyAddressChecksum(t *testing.T) {
+	err := smartcontract.VerifyAddressChecksum("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = smartcontract.VerifyAddressChecksum("AQV8F0Ni2o7EtMNn4etWBYx1cqBREAifgE")
+	if err == nil || err.Error() != "invalid character '0' at position 5" {
+		t.Fatalf("expected invalid character error got %v", err)
+	}
+
+	//valid characters but the last one is changed so the checksum doesn't match
+	err = smartcontract.VerifyAddressChecksum("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgF")
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestParseNEOAddressWithVersion(t *testing.T) {
+	scriptHash, _ := hex.DecodeString("5f8e3fcb095b55f53c44a1cab6e9c1a0da67cf87")
+
+	neo2Address := btckey.B58checkencodeNEO(smartcontract.NEO2AddressVersion, scriptHash)
+	neo3Address := btckey.B58checkencodeNEO(smartcontract.NEO3AddressVersion, scriptHash)
+	if neo2Address != "AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE" || neo3Address[0] != 'N' {
+		t.Fatalf("unexpected addresses %v %v", neo2Address, neo3Address)
+	}
+
+	//0x17 is the default
+	if bytes.Equal(smartcontract.ParseNEOAddress(neo2Address), scriptHash) == false {
+		t.Fail()
+	}
+	if smartcontract.ParseNEOAddress(neo3Address) != nil {
+		t.Fail()
+	}
+	if bytes.Equal(smartcontract.ParseNEOAddressWithVersion(neo3Address, smartcontract.NEO3AddressVersion), scriptHash) == false {
+		t.Fail()
+	}
+	if smartcontract.ParseNEOAddressWithVersion(neo2Address, smartcontract.NEO3AddressVersion) != nil {
+		t.Fail()
+	}
+}
+
+func TestAddressVersion(t *testing.T) {
+	scriptHash, _ := hex.DecodeString("5f8e3fcb095b55f53c44a1cab6e9c1a0da67cf87")
+	neo3Address := btckey.B58checkencodeNEO(smartcontract.NEO3AddressVersion, scriptHash)
+
+	smartcontract.AddressVersion = smartcontract.NEO3AddressVersion
+	defer func() { smartcontract.AddressVersion = smartcontract.NEO2AddressVersion }()
+
+	address := smartcontract.ParseNEOAddress(neo3Address)
+	if bytes.Equal(address, scriptHash) == false || address.ToString() != neo3Address {
+		t.Fail()
+	}
+	if smartcontract.IsValidAddressFormat(neo3Address) == false || smartcontract.VerifyAddressChecksum(neo3Address) != nil {
+		t.Fail()
+	}
+	if smartcontract.VerifyAddressChecksum("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE") == nil {
+		t.Fail()
+	}
+}
+
+func TestParseNEOAddressWithError(t *testing.T) {
+	address, err := smartcontract.ParseNEOAddressWithError("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(address, smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")) == false {
+		t.Fatalf("expected the same bytes as ParseNEOAddress got %x", address)
+	}
+
+	//last character changed so the checksum doesn't match
+	_, err = smartcontract.ParseNEOAddressWithError("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgF")
+	if err == nil {
+		t.Fatal("expected a Base58Check decode error")
+	}
+	if smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgF") != nil {
+		t.Fatal("ParseNEOAddress should still return nil")
+	}
+
+	neo3 := btckey.B58checkencodeNEO(smartcontract.NEO3AddressVersion, make([]byte, 20))
+	_, err = smartcontract.ParseNEOAddressWithError(neo3)
+	if err == nil {
+		t.Fatal("expected a version mismatch error")
+	}
+}
+
+func TestParseNEOAddressAmbiguousCharacter(t *testing.T) {
+	cases := map[string]string{
+		"AQV8FONi2o7EtMNn4etWBYx1cqBREAifgE": "invalid character 'O' at position 5",
+		"AQV8F0Ni2o7EtMNn4etWBYx1cqBREAifgE": "invalid character '0' at position 5",
+		"AQV8FNNi2o7EtMNn4etWBYx1cqBREAIfgE": "invalid character 'I' at position 30",
+		"AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgl": "invalid character 'l' at position 33",
+	}
+	for address, expected := range cases {
+		_, err := smartcontract.ParseNEOAddressWithError(address)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("%v expected %v got %v", address, expected, err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//name of an opcode the way neo-cli and the disassembler show it. false when the byte is not an opcode
+func opCodeName(op OpCode) (string, bool) {
+	if op >= PUSHBYTES1 && op <= PUSHBYTES75 {
+		return fmt.Sprintf("PUSHBYTES%d", byte(op)), true
+	}
+	for name, value := range AllOpCodes() {
+		if value == op {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+//opcode of a name. PUSHBYTES2-PUSHBYTES74 are not in AllOpCodes so they are read from the name
+func opCodeFromName(name string) (OpCode, bool) {
+	if strings.HasPrefix(name, "PUSHBYTES") {
+		n, err := strconv.Atoi(strings.TrimPrefix(name, "PUSHBYTES"))
+		if err != nil || n < int(PUSHBYTES1) || n > int(PUSHBYTES75) {
+			return 0, false
+		}
+		return OpCode(n), true
+	}
+	op, ok := AllOpCodes()[name]
+	return op, ok
+}
+
+//DisassembleScript returns the script as text with one instruction on each line e.g.
+//
+//	PUSHBYTES4 deadbeef
+//	JMP 5
+//	APPCALL ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9
+//	SYSCALL Neo.Runtime.CheckWitness
+//
+//pushed data is in hex, jumps are the signed offset from the jump, script hashes are big endian like explorers show them.
+//AssembleScript turns the text back into the same bytes
+func DisassembleScript(script []byte) (string, error) {
+	instructions, err := readInstructions(script)
+	if err != nil {
+		return "", err
+	}
+	lines := []string{}
+	for _, i := range instructions {
+		name, ok := opCodeName(i.OpCode)
+		if ok == false {
+			return "", fmt.Errorf("unknown opcode 0x%02x at %v", byte(i.OpCode), i.Offset)
+		}
+		switch {
+		case i.OpCode >= PUSHBYTES1 && i.OpCode <= PUSHDATA4:
+			lines = append(lines, name+" "+hex.EncodeToString(i.Operand))
+		case i.OpCode == JMP || i.OpCode == JMPIF || i.OpCode == JMPIFNOT || i.OpCode == CALL:
+			offset := int16(binary.LittleEndian.Uint16(i.Operand))
+			lines = append(lines, fmt.Sprintf("%v %d", name, offset))
+		case i.OpCode == APPCALL || i.OpCode == TAILCALL:
+			lines = append(lines, name+" "+ScriptHash(i.Operand).ToBigEndianString())
+		case i.OpCode == SYSCALL:
+			lines = append(lines, name+" "+string(i.Operand))
+		default:
+			lines = append(lines, name)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+//AssembleScript turns a listing in the format of DisassembleScript into a script.
+//empty lines and lines starting with // are skipped
+func AssembleScript(listing string) ([]byte, error) {
+	script := []byte{}
+	for number, line := range strings.Split(listing, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		b, err := assembleInstruction(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", number+1, err)
+		}
+		script = append(script, b...)
+	}
+	return script, nil
+}
+
+func assembleInstruction(line string) ([]byte, error) {
+	fields := strings.Fields(line)
+	name := strings.ToUpper(fields[0])
+	op, ok := opCodeFromName(name)
+	if ok == false {
+		return nil, fmt.Errorf("unknown opcode %v", fields[0])
+	}
+	operand := ""
+	if len(fields) > 1 {
+		operand = strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+	}
+	needsOperand := (op >= PUSHBYTES1 && op <= PUSHDATA4) || op == JMP || op == JMPIF || op == JMPIFNOT || op == CALL ||
+		op == APPCALL || op == TAILCALL || op == SYSCALL
+	if needsOperand == false {
+		if operand != "" {
+			return nil, fmt.Errorf("%v doesn't take an operand", name)
+		}
+		return []byte{byte(op)}, nil
+	}
+	if operand == "" {
+		return nil, fmt.Errorf("%v needs an operand", name)
+	}
+
+	b := []byte{byte(op)}
+	switch {
+	case op >= PUSHBYTES1 && op <= PUSHDATA4:
+		data, err := hex.DecodeString(strings.TrimPrefix(operand, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case PUSHDATA1:
+			if len(data) > 0xff {
+				return nil, fmt.Errorf("PUSHDATA1 can't push %v bytes", len(data))
+			}
+			b = append(b, byte(len(data)))
+		case PUSHDATA2:
+			if len(data) > 0xffff {
+				return nil, fmt.Errorf("PUSHDATA2 can't push %v bytes", len(data))
+			}
+			b = append(b, uint16ToFixBytes(uint16(len(data)))...)
+		case PUSHDATA4:
+			b = append(b, uint32ToFixBytes(uint32(len(data)))...)
+		default:
+			if len(data) != int(op) {
+				return nil, fmt.Errorf("%v pushes %v bytes but got %v", name, int(op), len(data))
+			}
+		}
+		return append(b, data...), nil
+	case op == JMP || op == JMPIF || op == JMPIFNOT || op == CALL:
+		offset, err := strconv.ParseInt(operand, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset %v", operand)
+		}
+		return append(b, uint16ToFixBytes(uint16(offset))...), nil
+	case op == APPCALL || op == TAILCALL:
+		scriptHash, err := NewScriptHash(operand)
+		if err != nil {
+			return nil, err
+		}
+		if len(scriptHash) != Uint160Length {
+			return nil, fmt.Errorf("invalid script hash %v", operand)
+		}
+		return append(b, scriptHash...), nil
+	}
+	//SYSCALL
+	if len(operand) > maxSysCallNameLength {
+		return nil, fmt.Errorf("SYSCALL name %v is too long", operand)
+	}
+	b = append(b, varIntBytes(uint64(len(operand)))...)
+	return append(b, []byte(operand)...), nil
+}
@@ -0,0 +1,82 @@
+package smartcontract_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestAssembleScript(t *testing.T) {
+	listing := `
+	//check the witness of the owner then jump over the THROW
+	PUSHBYTES4 deadbeef
+	SYSCALL Neo.Runtime.CheckWitness
+	JMPIF 3
+	THROW
+	PUSH0
+	APPCALL 0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9
+	`
+	script, err := smartcontract.AssembleScript(listing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "04deadbeef" + "68184e656f2e52756e74696d652e436865636b5769746e657373" + "630300" + "f0" + "00" + "67f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec"
+	if hex.EncodeToString(script) != expected {
+		t.Fatalf("expected %v got %x", expected, script)
+	}
+
+	_, err = smartcontract.AssembleScript("PUSHBYTES4 dead")
+	if err == nil {
+		t.Fatal("expected an error for PUSHBYTES4 with 2 bytes")
+	}
+	_, err = smartcontract.AssembleScript("NOTANOPCODE")
+	if err == nil {
+		t.Fatal("expected an error for an unknown opcode")
+	}
+}
+
+func TestDisassembleAssembleRoundTrip(t *testing.T) {
+	scriptHash, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	from := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	to := smartcontract.ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+	transfer := smartcontract.NewScriptBuilder().GenerateContractInvocationScript(scriptHash, "transfer", []interface{}{from, to, 100000000})
+
+	//JMP over two NOPs, CALL into the SYSCALL and JMP back
+	control, _ := hex.DecodeString("620500" + "6161" + "650400" + "66" + "68184e656f2e52756e74696d652e436865636b5769746e657373" + "62fdff")
+
+	//80 bytes goes in a PUSHDATA1
+	long := smartcontract.NewScriptBuilder()
+	long.Push(bytes.Repeat([]byte{0xab}, 80))
+	long.PushOpCode(smartcontract.DROP)
+	long.PushOpCode(smartcontract.RET)
+
+	cases := []struct {
+		script []byte
+		lines  []string
+	}{
+		{transfer, []string{"PUSH3", "PACK", "PUSHBYTES8 7472616e73666572", "APPCALL ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"}},
+		{control, []string{"JMP 5", "NOP", "CALL 4", "RET", "SYSCALL Neo.Runtime.CheckWitness", "JMP -3"}},
+		{long.ToBytes(), []string{"PUSHDATA1 " + strings.Repeat("ab", 80), "DROP", "RET"}},
+	}
+	for _, c := range cases {
+		listing, err := smartcontract.DisassembleScript(c.script)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range c.lines {
+			if strings.Contains("\n"+listing+"\n", "\n"+line+"\n") == false {
+				t.Fatalf("expected %v in\n%v", line, listing)
+			}
+		}
+		assembled, err := smartcontract.AssembleScript(listing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(assembled, c.script) == false {
+			t.Fatalf("expected %x got %x from\n%v", c.script, assembled, listing)
+		}
+	}
+}
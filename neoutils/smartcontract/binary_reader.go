@@ -0,0 +1,106 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+//binaryReader reads data serialized in NEO network format.
+//the first error is kept in err and every read after it returns zero values so callers only check err once
+type binaryReader struct {
+	b      []byte
+	offset int
+	err    error
+}
+
+func newBinaryReader(b []byte) *binaryReader {
+	return &binaryReader{b: b}
+}
+
+func (r *binaryReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *binaryReader) remaining() int {
+	return len(r.b) - r.offset
+}
+
+//copy of the bytes read from start until the current offset
+func (r *binaryReader) readSince(start int) []byte {
+	b := make([]byte, r.offset-start)
+	copy(b, r.b[start:r.offset])
+	return b
+}
+
+func (r *binaryReader) readBytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > r.remaining() {
+		r.fail(fmt.Errorf("unexpected end of data at %v reading %v bytes", r.offset, n))
+		return nil
+	}
+	b := make([]byte, n)
+	copy(b, r.b[r.offset:r.offset+n])
+	r.offset += n
+	return b
+}
+
+func (r *binaryReader) readByte() byte {
+	b := r.readBytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *binaryReader) readUint16() uint16 {
+	b := r.readBytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (r *binaryReader) readUint32() uint32 {
+	b := r.readBytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *binaryReader) readUint64() uint64 {
+	b := r.readBytes(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (r *binaryReader) readVarInt() uint64 {
+	switch prefix := r.readByte(); prefix {
+	case 0xfd:
+		return uint64(r.readUint16())
+	case 0xfe:
+		return uint64(r.readUint32())
+	case 0xff:
+		return r.readUint64()
+	default:
+		return uint64(prefix)
+	}
+}
+
+func (r *binaryReader) readVarBytes(max int) []byte {
+	length := r.readVarInt()
+	if r.err != nil {
+		return nil
+	}
+	if length > uint64(max) {
+		r.fail(fmt.Errorf("data at %v is %v bytes. maximum is %v bytes", r.offset, length, max))
+		return nil
+	}
+	return r.readBytes(int(length))
+}
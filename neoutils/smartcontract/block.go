@@ -0,0 +1,79 @@
+package smartcontract
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+//maximum number of transactions in a block
+const maxBlockTransactions = 65535
+
+//Block is a block deserialized from the raw bytes returned by getblock with verbose 0
+//https://github.com/neo-project/neo/blob/master/neo/Network/P2P/Payloads/Block.cs
+type Block struct {
+	Version           uint32
+	PreviousBlockHash Hash256
+	MerkleRoot        Hash256
+	Timestamp         uint32
+	Index             uint32
+	ConsensusData     uint64
+	NextConsensus     Hash160
+	Witness           TransactionValidationScript
+	Transactions      []*Transaction
+}
+
+//DeserializeBlock reads the header, the witness and every transaction of the block
+func DeserializeBlock(b []byte) (*Block, error) {
+	r := newBinaryReader(b)
+	block := &Block{}
+	block.Version = r.readUint32()
+	copy(block.PreviousBlockHash[:], r.readBytes(len(block.PreviousBlockHash)))
+	copy(block.MerkleRoot[:], r.readBytes(len(block.MerkleRoot)))
+	block.Timestamp = r.readUint32()
+	block.Index = r.readUint32()
+	block.ConsensusData = r.readUint64()
+	copy(block.NextConsensus[:], r.readBytes(Uint160Length))
+
+	//a block has exactly one witness
+	if count := r.readByte(); r.err == nil && count != 1 {
+		return nil, fmt.Errorf("expected 1 block witness but got %v", count)
+	}
+	block.Witness.StackScript = r.readVarBytes(maxWitnessScriptSize)
+	block.Witness.RedeemScript = r.readVarBytes(maxWitnessScriptSize)
+
+	count := r.readVarInt()
+	if r.err == nil && count > maxBlockTransactions {
+		return nil, fmt.Errorf("too many transactions in a block %v", count)
+	}
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		block.Transactions = append(block.Transactions, readTransaction(r))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("unexpected %v bytes after block", r.remaining())
+	}
+	return block, nil
+}
+
+//the header without the witness. the block hash is the hash of it
+func (b *Block) unsignedHeader() []byte {
+	payload := uint32ToFixBytes(b.Version)
+	payload = append(payload, b.PreviousBlockHash[:]...)
+	payload = append(payload, b.MerkleRoot[:]...)
+	payload = append(payload, uint32ToFixBytes(b.Timestamp)...)
+	payload = append(payload, uint32ToFixBytes(b.Index)...)
+	consensusData := make([]byte, 8)
+	binary.LittleEndian.PutUint64(consensusData, b.ConsensusData)
+	payload = append(payload, consensusData...)
+	payload = append(payload, b.NextConsensus[:]...)
+	return payload
+}
+
+//Hash returns the block hash in little endian. use String() for the form shown on explorers
+func (b *Block) Hash() Hash256 {
+	hash := sha256.Sum256(b.unsignedHeader())
+	return Hash256(sha256.Sum256(hash[:]))
+}
@@ -0,0 +1,49 @@
+package smartcontract
+
+import (
+	"fmt"
+	"sort"
+)
+
+//CalculateChange returns one output per asset that sends what is left back to the sender.
+//inputs are the selected UTXOs grouped by asset, outputs are the outputs to the receivers
+//and the network fee is taken from GAS. an asset without anything left doesn't get a change output
+func CalculateChange(sender NEOAddress, inputs map[NativeAsset][]UTXO, outputs []TransactionOutput, networkFeeAmount NetworkFeeAmount) ([]TransactionOutput, error) {
+	left := map[NativeAsset]Fixed8{}
+	for asset, utxos := range inputs {
+		for _, utxo := range utxos {
+			left[asset] += NewFixed8(utxo.Value)
+		}
+	}
+	for _, output := range outputs {
+		left[output.Asset] -= output.Value
+	}
+	left[GAS] -= NewFixed8(float64(networkFeeAmount))
+
+	//same order every time so the transaction is the same for the same inputs
+	assets := []NativeAsset{}
+	for asset := range left {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i] < assets[j] })
+
+	change := []TransactionOutput{}
+	for _, asset := range assets {
+		amount := left[asset]
+		if amount < 0 {
+			if asset == GAS && networkFeeAmount > 0 {
+				return nil, fmt.Errorf("inputs of asset %v are %v short of the outputs and network fee", asset, -amount)
+			}
+			return nil, fmt.Errorf("inputs of asset %v are %v short of the outputs", asset, -amount)
+		}
+		if amount == 0 {
+			continue
+		}
+		change = append(change, TransactionOutput{
+			Asset:   asset,
+			Value:   amount,
+			Address: sender,
+		})
+	}
+	return change, nil
+}
@@ -0,0 +1,53 @@
+package smartcontract_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestCalculateChangeNEOAndGAS(t *testing.T) {
+	sender := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	receiver := smartcontract.ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+	inputs := map[smartcontract.NativeAsset][]smartcontract.UTXO{
+		smartcontract.NEO: {
+			{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 10},
+		},
+		smartcontract.GAS: {
+			{Index: 1, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1.5},
+			{Index: 0, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 0.5},
+		},
+	}
+	outputs := []smartcontract.TransactionOutput{
+		{Asset: smartcontract.NEO, Value: 3 * 100000000, Address: receiver},
+	}
+
+	change, err := smartcontract.CalculateChange(sender, inputs, outputs, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(change) != 2 {
+		t.Fatalf("expected 2 change outputs got %+v", change)
+	}
+	for _, output := range change {
+		if output.Address.ToString() != "AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE" {
+			t.Fail()
+		}
+		switch output.Asset {
+		case smartcontract.NEO:
+			if output.Value != 7*100000000 {
+				t.Fatalf("expected 7 NEO change got %v", output.Value)
+			}
+		case smartcontract.GAS:
+			if output.Value != 150000000 {
+				t.Fatalf("expected 1.5 GAS change got %v", output.Value)
+			}
+		}
+	}
+
+	//network fee more than GAS inputs
+	_, err = smartcontract.CalculateChange(sender, inputs, outputs, 3)
+	if err == nil {
+		t.Fail()
+	}
+}
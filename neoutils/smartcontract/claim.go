@@ -0,0 +1,114 @@
+package smartcontract
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+//SpentCoin is a NEO output that has been spent but its GAS hasn't been claimed yet.
+//StartHeight is the block the output was created and EndHeight is the block it was spent
+type SpentCoin struct {
+	Value       float64 //NEO
+	StartHeight uint32
+	EndHeight   uint32
+}
+
+//GenerationSchedule is the amount of GAS generated per block. it decreases every DecrementInterval blocks
+type GenerationSchedule struct {
+	DecrementInterval uint32
+	GenerationAmount  []uint32
+}
+
+//https://github.com/neo-project/neo/blob/master/neo/Ledger/Blockchain.cs
+var NEOGenerationSchedule = GenerationSchedule{
+	DecrementInterval: 2000000,
+	GenerationAmount:  []uint32{8, 7, 6, 5, 4, 3, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+}
+
+//total amount of NEO. GAS generated in a block is shared by all NEO holders
+const neoTotalSupply = 100000000
+
+//returns the amount of GAS generated from startHeight until endHeight (exclusive) for the whole NEO supply
+func (g GenerationSchedule) generatedGAS(startHeight uint32, endHeight uint32) uint64 {
+	amount := uint64(0)
+	interval := g.DecrementInterval
+	ustart := startHeight / interval
+	if ustart >= uint32(len(g.GenerationAmount)) {
+		return 0
+	}
+	istart := startHeight % interval
+	uend := endHeight / interval
+	iend := endHeight % interval
+	if uend >= uint32(len(g.GenerationAmount)) {
+		uend = uint32(len(g.GenerationAmount))
+		iend = 0
+	}
+	if iend == 0 {
+		uend--
+		iend = interval
+	}
+	for ustart < uend {
+		amount += uint64(interval-istart) * uint64(g.GenerationAmount[ustart])
+		ustart++
+		istart = 0
+	}
+	amount += uint64(iend-istart) * uint64(g.GenerationAmount[ustart])
+	return amount
+}
+
+//CalculateClaimableGAS returns the GAS that can be claimed from the spent coins.
+//accumulatedSystemFee returns the total system fee in GAS paid from the genesis block until the given height (inclusive)
+func CalculateClaimableGAS(coins []SpentCoin, schedule GenerationSchedule, accumulatedSystemFee func(height uint32) float64) (float64, error) {
+	if schedule.DecrementInterval == 0 || len(schedule.GenerationAmount) == 0 {
+		return 0, fmt.Errorf("invalid generation schedule")
+	}
+	//sum in fixed8 and only divide at the end to avoid losing precision
+	total := big.NewInt(0)
+	for _, coin := range coins {
+		if coin.EndHeight <= coin.StartHeight {
+			return 0, fmt.Errorf("invalid coin height range %v-%v", coin.StartHeight, coin.EndHeight)
+		}
+		amount := int64(schedule.generatedGAS(coin.StartHeight, coin.EndHeight)) * 100000000
+
+		systemFee := accumulatedSystemFee(coin.EndHeight - 1)
+		if coin.StartHeight > 0 {
+			systemFee -= accumulatedSystemFee(coin.StartHeight - 1)
+		}
+		amount += int64(math.Round(systemFee * 100000000))
+
+		value := big.NewInt(int64(math.Round(coin.Value)))
+		total.Add(total, value.Mul(value, big.NewInt(amount)))
+	}
+	total.Div(total, big.NewInt(neoTotalSupply))
+	return float64(total.Int64()) / float64(100000000), nil
+}
+
+//GenerateClaimTransactionData returns the exclusive data of a claim transaction
+//[var int count] + N x ([txid in little endian] + [index uint16]) of the spent NEO outputs being claimed
+func (s *ScriptBuilder) GenerateClaimTransactionData(claims []UTXO) ([]byte, error) {
+	if len(claims) == 0 {
+		return nil, fmt.Errorf("nothing to claim")
+	}
+	err := s.pushInputs(claims)
+	if err != nil {
+		return nil, err
+	}
+	return s.ToBytes(), nil
+}
+
+//GenerateClaimTransactionOutput returns the outputs section of a claim transaction. the claimed GAS goes to a single address
+func (s *ScriptBuilder) GenerateClaimTransactionOutput(receiver NEOAddress, amount float64) ([]byte, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("claim amount must be more than 0")
+	}
+	err := s.pushOutputs([]TransactionOutput{{
+		Asset:   GAS,
+		Value:   NewFixed8(amount),
+		Address: receiver,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return s.ToBytes(), nil
+}
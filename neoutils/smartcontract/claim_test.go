@@ -0,0 +1,51 @@
+package smartcontract_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+//1 GAS of system fee in every block
+func oneGASPerBlockSystemFee(height uint32) float64 {
+	return float64(height)
+}
+
+func TestCalculateClaimableGAS(t *testing.T) {
+	coins := []smartcontract.SpentCoin{
+		{Value: 100, StartHeight: 0, EndHeight: 10},
+	}
+	//10 blocks x 8 GAS + 9 GAS system fee for 100 out of 100,000,000 NEO
+	claimable, err := smartcontract.CalculateClaimableGAS(coins, smartcontract.NEOGenerationSchedule, oneGASPerBlockSystemFee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimable != 0.000089 {
+		t.Fatalf("expected 0.000089 got %v", claimable)
+	}
+}
+
+func TestCalculateClaimableGASAcrossDecrementInterval(t *testing.T) {
+	schedule := smartcontract.GenerationSchedule{
+		DecrementInterval: 10,
+		GenerationAmount:  []uint32{8, 7, 6},
+	}
+	coins := []smartcontract.SpentCoin{
+		//10 x 8 + 9
+		{Value: 100, StartHeight: 0, EndHeight: 10},
+		//5 x 8 + 10 x 7 + 5 x 6 + 20
+		{Value: 1000, StartHeight: 5, EndHeight: 25},
+	}
+	claimable, err := smartcontract.CalculateClaimableGAS(coins, schedule, oneGASPerBlockSystemFee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimable != 0.001689 {
+		t.Fatalf("expected 0.001689 got %v", claimable)
+	}
+
+	_, err = smartcontract.CalculateClaimableGAS([]smartcontract.SpentCoin{{Value: 1, StartHeight: 10, EndHeight: 10}}, schedule, oneGASPerBlockSystemFee)
+	if err == nil {
+		t.Fail()
+	}
+}
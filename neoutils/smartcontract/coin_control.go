@@ -0,0 +1,97 @@
+package smartcontract
+
+import (
+	"fmt"
+)
+
+//amount the given UTXOs of assetToSend must cover. the fee is included when it's paid in the same asset
+func requiredAmountFromUTXOs(utxos []UTXO, assetToSend NativeAsset, amountToSend float64, networkFeeAmount NetworkFeeAmount) (Fixed8, Fixed8, error) {
+	if len(utxos) == 0 {
+		return 0, 0, fmt.Errorf("no UTXO given")
+	}
+	required := NewFixed8(amountToSend)
+	if networkFeeAmount > 0 {
+		//the fee is always GAS
+		if assetToSend != GAS {
+			return 0, 0, fmt.Errorf("network fee must be paid in GAS but the UTXOs are %v", assetToSend)
+		}
+		required = required.Add(NewFixed8(float64(networkFeeAmount)))
+	}
+	total := Fixed8(0)
+	for _, utxo := range utxos {
+		total = total.Add(NewFixed8(utxo.Value))
+	}
+	if required > total {
+		return 0, 0, fmt.Errorf("%w. Sending %v but the given UTXOs only have %v", ErrInsufficientBalance, required, total)
+	}
+	return required, total, nil
+}
+
+//GenerateTransactionInputFromUTXOs spends exactly the given UTXOs instead of selecting them from a balance (coin control).
+//the UTXOs must cover amountToSend and the network fee when assetToSend is GAS
+func (s *ScriptBuilder) GenerateTransactionInputFromUTXOs(utxos []UTXO, assetToSend NativeAsset, amountToSend float64, networkFeeAmount NetworkFeeAmount) ([]byte, error) {
+	_, _, err := requiredAmountFromUTXOs(utxos, assetToSend, amountToSend, networkFeeAmount)
+	if err != nil {
+		return nil, err
+	}
+	err = s.pushInputs(utxos)
+	if err != nil {
+		return nil, err
+	}
+	return s.ToBytes(), nil
+}
+
+//GenerateTransactionOutputFromUTXOs returns the outputs for inputs made by GenerateTransactionInputFromUTXOs.
+//whatever is left after the amount and the fee goes back to the sender
+func (s *ScriptBuilder) GenerateTransactionOutputFromUTXOs(sender NEOAddress, receiver NEOAddress, utxos []UTXO, assetToSend NativeAsset, amountToSend float64, networkFeeAmount NetworkFeeAmount) ([]byte, error) {
+	required, total, err := requiredAmountFromUTXOs(utxos, assetToSend, amountToSend, networkFeeAmount)
+	if err != nil {
+		return nil, err
+	}
+	list := []TransactionOutput{
+		{Asset: assetToSend, Value: NewFixed8(amountToSend), Address: receiver},
+	}
+	if total != required && s.isDust(assetToSend, total.Sub(required)) == false {
+		list = append(list, TransactionOutput{Asset: assetToSend, Value: total.Sub(required), Address: sender})
+	}
+
+	if s.CheckMaximumAmount == true {
+		for _, v := range list {
+			err := v.Asset.ValidateAmount(v.Value.Float64())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = s.pushOutputs(list)
+	if err != nil {
+		return nil, err
+	}
+	return s.ToBytes(), nil
+}
+
+//SelectUTXOsFromChannel takes UTXOs from the channel in the order they come until they cover amount
+//and stops reading from the channel then. it's for a large set of UTXOs fetched a page at a time.
+//the UTXOs are not sorted so the selection can differ from GenerateTransactionInput.
+//spend the selected UTXOs with GenerateTransactionInputFromUTXOs and GenerateTransactionOutputFromUTXOs
+func (s *ScriptBuilder) SelectUTXOsFromChannel(utxos <-chan UTXO, amount float64) ([]UTXO, Fixed8, error) {
+	required := NewFixed8(amount)
+	selected := []UTXO{}
+	sum := Fixed8(0)
+	for sum < required {
+		utxo, ok := <-utxos
+		if ok == false {
+			return nil, 0, fmt.Errorf("%w. Sending %v but only have %v", ErrInsufficientBalance, amount, sum)
+		}
+		if s.MinimumConfirmations > 0 && utxo.Confirmations < s.MinimumConfirmations {
+			continue
+		}
+		if s.MaxInputs > 0 && len(selected) == s.MaxInputs {
+			return nil, 0, fmt.Errorf("%w. Sending %v needs more than %v inputs. send the balance to yourself first to consolidate the UTXOs", ErrTooManyInputs, amount, s.MaxInputs)
+		}
+		selected = append(selected, utxo)
+		sum = sum.Add(NewFixed8(utxo.Value))
+	}
+	return selected, sum, nil
+}
@@ -0,0 +1,144 @@
+package smartcontract_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestGenerateTransactionInputFromUTXOs(t *testing.T) {
+	//the automatic selection would pick the 1 GAS UTXO first
+	utxos := []smartcontract.UTXO{
+		{Index: 1, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 5},
+		{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 3},
+	}
+
+	b, err := smartcontract.NewScriptBuilder().GenerateTransactionInputFromUTXOs(utxos, smartcontract.GAS, 7, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "02" + reversedHex(utxos[0].TXID) + "0100" + reversedHex(utxos[1].TXID) + "0000"
+	if hex.EncodeToString(b) != expected {
+		t.Fatalf("expected %v got %x", expected, b)
+	}
+
+	sender := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	receiver := smartcontract.ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+	outputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutputFromUTXOs(sender, receiver, utxos, smartcontract.GAS, 7, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//7 to the receiver and 0.5 back to the sender
+	gas := reversedHex(string(smartcontract.GAS))
+	expected = "02" + gas + "0027b92900000000" + hex.EncodeToString(receiver) + gas + "80f0fa0200000000" + hex.EncodeToString(sender)
+	if hex.EncodeToString(outputs) != expected {
+		t.Fatalf("expected %v got %x", expected, outputs)
+	}
+
+	//the UTXOs don't cover the amount and the fee
+	_, err = smartcontract.NewScriptBuilder().GenerateTransactionInputFromUTXOs(utxos, smartcontract.GAS, 8, 0.5)
+	if errors.Is(err, smartcontract.ErrInsufficientBalance) == false {
+		t.Fatalf("expected ErrInsufficientBalance got %v", err)
+	}
+	_, err = smartcontract.NewScriptBuilder().GenerateTransactionInputFromUTXOs(nil, smartcontract.GAS, 1, 0)
+	if err == nil {
+		t.Fatal("expected an error without UTXOs")
+	}
+}
+
+func TestGenerateTransactionInputDuplicateUTXO(t *testing.T) {
+	utxo := smartcontract.UTXO{Index: 1, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 5}
+	_, err := smartcontract.NewScriptBuilder().GenerateTransactionInputFromUTXOs([]smartcontract.UTXO{utxo, utxo}, smartcontract.GAS, 7, 0)
+	if err == nil || strings.Contains(err.Error(), "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0:1") == false {
+		t.Fatalf("expected an error for the duplicate input got %v", err)
+	}
+
+	//the same input in a raw transaction
+	tx := smartcontract.NewContractTransaction()
+	tx.Attributes = []byte{0x00}
+	single, err := smartcontract.NewScriptBuilder().GenerateTransactionInputFromUTXOs([]smartcontract.UTXO{utxo}, smartcontract.GAS, 5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Inputs = append([]byte{0x02}, append(single[1:], single[1:]...)...)
+	tx.Outputs = []byte{0x00}
+	_, err = smartcontract.DeserializeTransaction(tx.ToBytes())
+	if err == nil {
+		t.Fatal("expected an error for the duplicate input")
+	}
+}
+
+func TestValidateBalances(t *testing.T) {
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.NEO: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{{Index: 0, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 5}},
+			},
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1}},
+			},
+		},
+	}
+	s := smartcontract.NewScriptBuilder()
+	err := s.ValidateBalances(unspent, map[smartcontract.NativeAsset]float64{smartcontract.NEO: 5, smartcontract.GAS: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//both assets are short and the error has both of them
+	err = s.ValidateBalances(unspent, map[smartcontract.NativeAsset]float64{smartcontract.NEO: 10, smartcontract.GAS: 1.5})
+	if errors.Is(err, smartcontract.ErrInsufficientBalance) == false {
+		t.Fatalf("expected ErrInsufficientBalance got %v", err)
+	}
+	if strings.Contains(err.Error(), "NEO needs 10") == false || strings.Contains(err.Error(), "GAS needs 1.5") == false {
+		t.Fatalf("expected both NEO and GAS in the error got %v", err)
+	}
+}
+
+func TestSelectUTXOsFromChannel(t *testing.T) {
+	utxos := make(chan smartcontract.UTXO)
+	done := make(chan struct{})
+	sent := 0
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			select {
+			case utxos <- smartcontract.UTXO{Index: i, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1}:
+				sent++
+			case <-done:
+				return
+			}
+		}
+		close(utxos)
+	}()
+
+	selected, sum, err := smartcontract.NewScriptBuilder().SelectUTXOsFromChannel(utxos, 2.5)
+	close(done)
+	wg.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected) != 3 || sum != smartcontract.NewFixed8(3) {
+		t.Fatalf("expected 3 UTXOs of 3 got %v of %v", len(selected), sum)
+	}
+	//nothing more is read once the amount is covered
+	if sent != 3 {
+		t.Fatalf("expected 3 UTXOs read from the channel got %v", sent)
+	}
+
+	//the channel closes before the amount is covered
+	short := make(chan smartcontract.UTXO, 2)
+	short <- smartcontract.UTXO{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1}
+	short <- smartcontract.UTXO{Index: 1, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1}
+	close(short)
+	_, _, err = smartcontract.NewScriptBuilder().SelectUTXOsFromChannel(short, 2.5)
+	if errors.Is(err, smartcontract.ErrInsufficientBalance) == false {
+		t.Fatalf("expected ErrInsufficientBalance got %v", err)
+	}
+}
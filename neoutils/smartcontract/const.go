@@ -1,6 +1,9 @@
 package smartcontract
 
-import "encoding/hex"
+import (
+	"encoding/hex"
+	"fmt"
+)
 
 type NativeAsset string
 type TradingVersion byte //currently 0
@@ -16,11 +19,46 @@ const (
 	GAS NativeAsset = "602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7"
 )
 
+var nativeAssetNames = map[NativeAsset]string{
+	NEO: "NEO",
+	GAS: "GAS",
+}
+
+//String returns NEO or GAS for the native assets and the asset ID in hex for others
+func (n NativeAsset) String() string {
+	name, ok := nativeAssetNames[n]
+	if !ok {
+		return string(n)
+	}
+	return name
+}
+
 var NativeAssets = map[string]NativeAsset{
 	"c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b": NEO,
 	"602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7": GAS,
 }
 
+//maximum amount of each native asset that can ever exist.
+//NEO total supply is 100,000,000 and GAS will never exceed 100,000,000
+//anything over this is almost certainly a fixed8 vs whole number mixup
+var NativeAssetMaximumAmount = map[NativeAsset]float64{
+	NEO: 100000000,
+	GAS: 100000000,
+}
+
+//ValidateAmount returns an error when the amount is more than the maximum supply of the asset
+//amount is in whole number e.g. 1.5 GAS not 150000000
+func (n NativeAsset) ValidateAmount(amount float64) error {
+	maximum, ok := NativeAssetMaximumAmount[n]
+	if !ok {
+		return nil
+	}
+	if amount > maximum {
+		return fmt.Errorf("amount %v exceeds the maximum supply of asset %v (%v)", amount, n, maximum)
+	}
+	return nil
+}
+
 func (n NativeAsset) ToLittleEndianBytes() []byte {
 	b, err := hex.DecodeString(string(n))
 	if err != nil {
@@ -2,9 +2,32 @@ package smartcontract
 
 import (
 	"log"
+	"strings"
 	"testing"
 )
 
 func TestNativeAsset(t *testing.T) {
 	log.Printf("%x", NEO.ToLittleEndianBytes())
 }
+
+func TestNativeAssetString(t *testing.T) {
+	if NEO.String() != "NEO" || GAS.String() != "GAS" {
+		t.Fail()
+	}
+	other := NativeAsset("a52a1b27e5a2b9b0f5ee9e3e4d1d3b5e9c7f1c2d3e4f5a6b7c8d9e0f1a2b3c4d")
+	if other.String() != string(other) {
+		t.Fail()
+	}
+}
+
+func TestAssetNotFoundErrorMessage(t *testing.T) {
+	unspent := Unspent{
+		Assets: map[NativeAsset]*Balance{
+			GAS: &Balance{UTXOs: []UTXO{{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1}}},
+		},
+	}
+	_, err := NewScriptBuilder().GenerateTransactionInput(unspent, NEO, 1, 0)
+	if err == nil || strings.HasPrefix(err.Error(), "Asset NEO not found") == false {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package smartcontract
+
+import (
+	"fmt"
+	"math/big"
+)
+
+//ContractParameterType is the type of a parameter in a contract ABI
+//https://github.com/neo-project/neo/blob/master/neo/SmartContract/ContractParameterType.cs
+type ContractParameterType byte
+
+const (
+	SignatureType ContractParameterType = 0x00
+	BooleanType   ContractParameterType = 0x01
+	IntegerType   ContractParameterType = 0x02
+	Hash160Type   ContractParameterType = 0x03
+	Hash256Type   ContractParameterType = 0x04
+	ByteArrayType ContractParameterType = 0x05
+	PublicKeyType ContractParameterType = 0x06
+	StringType    ContractParameterType = 0x07
+	ArrayType     ContractParameterType = 0x10
+	MapType       ContractParameterType = 0x12
+)
+
+//ContractParameter is an argument with an explicit type so it's pushed exactly the way the contract expects.
+//Value for each type
+//Signature: []byte 64 bytes
+//Boolean: bool
+//Integer: int, int64 or *big.Int
+//Hash160: Hash160, []byte 20 bytes in little endian, ScriptHash or NEOAddress
+//Hash256: Hash256 or []byte 32 bytes in little endian
+//ByteArray: []byte
+//PublicKey: []byte 33 bytes compressed public key
+//String: string pushed as UTF8 bytes
+//Array: []ContractParameter
+//Map: []ContractParameterPair
+type ContractParameter struct {
+	Type  ContractParameterType
+	Value interface{}
+}
+
+type ContractParameterPair struct {
+	Key   ContractParameter
+	Value ContractParameter
+}
+
+func (s *ScriptBuilder) pushFixedLengthBytes(value interface{}, length int, name string) error {
+	b, ok := value.([]byte)
+	if !ok || len(b) != length {
+		return fmt.Errorf("%v parameter must be %v bytes", name, length)
+	}
+	return s.pushData(b)
+}
+
+func (s *ScriptBuilder) pushBigInt(value *big.Int) error {
+	if value.IsInt64() {
+		return s.pushInt64(value.Int64())
+	}
+	return s.pushData(bigIntToBytes(value))
+}
+
+func (s *ScriptBuilder) pushContractParameter(p ContractParameter) error {
+	switch p.Type {
+	case SignatureType:
+		return s.pushFixedLengthBytes(p.Value, 64, "Signature")
+	case BooleanType:
+		v, ok := p.Value.(bool)
+		if !ok {
+			return fmt.Errorf("Boolean parameter must be bool")
+		}
+		return s.pushData(v)
+	case IntegerType:
+		switch v := p.Value.(type) {
+		case int:
+			return s.pushInt64(int64(v))
+		case int64:
+			return s.pushInt64(v)
+		case *big.Int:
+			return s.pushBigInt(v)
+		}
+		return fmt.Errorf("Integer parameter must be int, int64 or *big.Int")
+	case Hash160Type:
+		switch v := p.Value.(type) {
+		case NEOAddress, Hash160:
+			return s.pushData(v)
+		case ScriptHash:
+			return s.pushFixedLengthBytes([]byte(v), Uint160Length, "Hash160")
+		}
+		return s.pushFixedLengthBytes(p.Value, Uint160Length, "Hash160")
+	case Hash256Type:
+		if v, ok := p.Value.(Hash256); ok {
+			return s.pushData(v)
+		}
+		return s.pushFixedLengthBytes(p.Value, 32, "Hash256")
+	case ByteArrayType:
+		v, ok := p.Value.([]byte)
+		if !ok {
+			return fmt.Errorf("ByteArray parameter must be []byte")
+		}
+		return s.pushData(v)
+	case PublicKeyType:
+		return s.pushFixedLengthBytes(p.Value, publicKeyLength, "PublicKey")
+	case StringType:
+		v, ok := p.Value.(string)
+		if !ok {
+			return fmt.Errorf("String parameter must be string")
+		}
+		return s.pushData([]byte(v))
+	case ArrayType:
+		v, ok := p.Value.([]ContractParameter)
+		if !ok {
+			return fmt.Errorf("Array parameter must be []ContractParameter")
+		}
+		//reverse the array first
+		for i := len(v) - 1; i >= 0; i-- {
+			err := s.pushContractParameter(v[i])
+			if err != nil {
+				return err
+			}
+		}
+		s.pushInt(len(v))
+		s.PushOpCode(PACK)
+		return nil
+	case MapType:
+		v, ok := p.Value.([]ContractParameterPair)
+		if !ok {
+			return fmt.Errorf("Map parameter must be []ContractParameterPair")
+		}
+		//NEWMAP then DUP [key] [value] SETITEM for every pair. the map stays on the stack
+		s.PushOpCode(NEWMAP)
+		for _, pair := range v {
+			s.PushOpCode(DUP)
+			err := s.pushContractParameter(pair.Key)
+			if err != nil {
+				return err
+			}
+			err = s.pushContractParameter(pair.Value)
+			if err != nil {
+				return err
+			}
+			s.PushOpCode(SETITEM)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported contract parameter type 0x%02x", byte(p.Type))
+}
+
+//EmitArray pushes params as one PACKed array with the encoding of each parameter type.
+//nothing is pushed when one of the parameters is invalid
+func (s *ScriptBuilder) EmitArray(params []ContractParameter) error {
+	array := &ScriptBuilder{RawBytes: []byte{}, MaxItemSize: s.MaxItemSize}
+	err := array.pushContractParameter(ContractParameter{Type: ArrayType, Value: params})
+	if err != nil {
+		return err
+	}
+	s.RawBytes = append(s.RawBytes, array.RawBytes...)
+	return s.flush()
+}
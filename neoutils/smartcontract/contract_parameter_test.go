@@ -0,0 +1,112 @@
+package smartcontract_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestPushContractParameter(t *testing.T) {
+	address := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	hash160 := []byte(address)
+	hash256 := bytes.Repeat([]byte{0x01}, 32)
+	signature := bytes.Repeat([]byte{0x02}, 64)
+	publicKey, _ := hex.DecodeString("02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986")
+	bigValue, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+	cases := []struct {
+		parameter smartcontract.ContractParameter
+		expected  string
+	}{
+		{smartcontract.ContractParameter{Type: smartcontract.SignatureType, Value: signature}, "40" + hex.EncodeToString(signature)},
+		{smartcontract.ContractParameter{Type: smartcontract.BooleanType, Value: true}, "51"},
+		{smartcontract.ContractParameter{Type: smartcontract.BooleanType, Value: false}, "00"},
+		{smartcontract.ContractParameter{Type: smartcontract.IntegerType, Value: 5}, "55"},
+		{smartcontract.ContractParameter{Type: smartcontract.IntegerType, Value: int64(1000)}, "02e803"},
+		{smartcontract.ContractParameter{Type: smartcontract.IntegerType, Value: bigValue}, "09000010632d5ec76b05"},
+		{smartcontract.ContractParameter{Type: smartcontract.IntegerType, Value: big.NewInt(-1)}, "4f"},
+		{smartcontract.ContractParameter{Type: smartcontract.Hash160Type, Value: hash160}, "14" + hex.EncodeToString(hash160)},
+		{smartcontract.ContractParameter{Type: smartcontract.Hash160Type, Value: address}, "14" + hex.EncodeToString(hash160)},
+		{smartcontract.ContractParameter{Type: smartcontract.Hash256Type, Value: hash256}, "20" + hex.EncodeToString(hash256)},
+		{smartcontract.ContractParameter{Type: smartcontract.ByteArrayType, Value: []byte{0xab, 0xcd}}, "02abcd"},
+		{smartcontract.ContractParameter{Type: smartcontract.PublicKeyType, Value: publicKey}, "21" + hex.EncodeToString(publicKey)},
+		//a string is UTF8 not hex
+		{smartcontract.ContractParameter{Type: smartcontract.StringType, Value: "abcd"}, "0461626364"},
+		{smartcontract.ContractParameter{Type: smartcontract.ArrayType, Value: []smartcontract.ContractParameter{
+			{Type: smartcontract.IntegerType, Value: 1},
+			{Type: smartcontract.StringType, Value: "a"},
+		}}, "0161" + "51" + "52" + "c1"},
+		{smartcontract.ContractParameter{Type: smartcontract.MapType, Value: []smartcontract.ContractParameterPair{
+			{Key: smartcontract.ContractParameter{Type: smartcontract.StringType, Value: "a"}, Value: smartcontract.ContractParameter{Type: smartcontract.IntegerType, Value: 2}},
+		}}, "c7" + "76" + "0161" + "52" + "c4"},
+	}
+
+	for _, c := range cases {
+		sb := smartcontract.NewScriptBuilder()
+		err := sb.Push(c.parameter)
+		if err != nil {
+			t.Fatalf("%+v %v", c.parameter, err)
+		}
+		if sb.FullHexString() != c.expected {
+			t.Fatalf("type 0x%02x expected %v got %v", byte(c.parameter.Type), c.expected, sb.FullHexString())
+		}
+	}
+}
+
+func TestPushContractParameterInvalidValue(t *testing.T) {
+	invalid := []smartcontract.ContractParameter{
+		{Type: smartcontract.SignatureType, Value: []byte{0x01}},
+		{Type: smartcontract.BooleanType, Value: 1},
+		{Type: smartcontract.IntegerType, Value: "1"},
+		{Type: smartcontract.Hash160Type, Value: make([]byte, 32)},
+		{Type: smartcontract.Hash256Type, Value: make([]byte, 20)},
+		{Type: smartcontract.PublicKeyType, Value: make([]byte, 65)},
+		{Type: smartcontract.StringType, Value: []byte("a")},
+		{Type: smartcontract.ArrayType, Value: []interface{}{1}},
+		{Type: smartcontract.ContractParameterType(0xf0), Value: nil},
+	}
+	for _, p := range invalid {
+		err := smartcontract.NewScriptBuilder().Push(p)
+		if err == nil {
+			t.Fatalf("expected an error for %+v", p)
+		}
+		if strings.TrimSpace(err.Error()) == "" {
+			t.Fail()
+		}
+	}
+}
+
+func TestEmitArray(t *testing.T) {
+	hash, err := smartcontract.NewHash160("0xce575ae1bb6153330d20c560acb434dc5755241b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb := smartcontract.NewScriptBuilder()
+	err = sb.EmitArray([]smartcontract.ContractParameter{
+		{Type: smartcontract.IntegerType, Value: 1000},
+		{Type: smartcontract.Hash160Type, Value: hash},
+		{Type: smartcontract.StringType, Value: "neo"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//in reverse order then PUSH3 PACK
+	expected := "036e656f" + "14" + hex.EncodeToString(hash.ToLittleEndianBytes()) + "02e803" + "53" + "c1"
+	if sb.FullHexString() != expected {
+		t.Fatalf("expected %v got %v", expected, sb.FullHexString())
+	}
+
+	//nothing is pushed when a parameter is invalid
+	sb = smartcontract.NewScriptBuilder()
+	err = sb.EmitArray([]smartcontract.ContractParameter{
+		{Type: smartcontract.IntegerType, Value: 1},
+		{Type: smartcontract.StringType, Value: 1},
+	})
+	if err == nil || len(sb.ToBytes()) != 0 {
+		t.Fatalf("expected an error and an empty script got %v %x", err, sb.ToBytes())
+	}
+}
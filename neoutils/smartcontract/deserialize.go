@@ -0,0 +1,236 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	transactionInputLength       = 34 //[txID(32)] + [index(2)]
+	transactionOutputLength      = 60 //[assetID(32)] + [amount(8)] + [script hash(20)]
+	maxInvocationScriptSize      = 65536
+	maxAssetNameLength           = 1024
+	maxContractScriptSize        = 0x1000000
+	maxContractFieldLength       = 252
+	maxContractDescriptionLength = 65536
+	maxAgencyOrders              = 0x10000000
+)
+
+//DeserializeTransaction reads a raw transaction back to the Transaction struct.
+//each section keeps the same bytes the builder generates so ToBytes returns the raw transaction again.
+//an unsigned transaction ends after the outputs. anything after the witnesses is an error
+func DeserializeTransaction(b []byte) (*Transaction, error) {
+	r := newBinaryReader(b)
+	t := readUnsignedTransaction(r)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.remaining() == 0 {
+		t.Script = []byte{}
+		return t, nil
+	}
+	//scripts
+	start := r.offset
+	readTransactionScripts(r)
+	t.Script = r.readSince(start)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("unexpected %v bytes after the transaction", r.remaining())
+	}
+	return t, nil
+}
+
+//reads a transaction followed by other data e.g. in a block. the witnesses are read exactly to know where the transaction ends
+func readTransaction(r *binaryReader) *Transaction {
+	t := readUnsignedTransaction(r)
+	start := r.offset
+	readTransactionScripts(r)
+	t.Script = r.readSince(start)
+	return t
+}
+
+func readUnsignedTransaction(r *binaryReader) *Transaction {
+	t := &Transaction{}
+	t.Type = TransactionType(r.readByte())
+	t.Version = TradingVersion(r.readByte())
+
+	start := r.offset
+	readExclusiveData(r, t)
+	t.Data = r.readSince(start)
+
+	start = r.offset
+	readTransactionAttributes(r)
+	t.Attributes = r.readSince(start)
+
+	start = r.offset
+	inputs := readFixedLengthItems(r, transactionInputLength)
+	t.Inputs = r.readSince(start)
+	r.fail(checkDuplicateReferences(inputs))
+
+	start = r.offset
+	readFixedLengthItems(r, transactionOutputLength)
+	t.Outputs = r.readSince(start)
+	return t
+}
+
+//exclusive data of each transaction type
+//https://github.com/neo-project/neo/tree/master/neo/Network/P2P/Payloads
+func readExclusiveData(r *binaryReader, t *Transaction) {
+	switch t.Type {
+	case MinerTransaction:
+		r.readUint32() //nonce
+	case ContractTransaction, IssueTransaction:
+		return
+	case InvocationTransaction:
+		r.readVarBytes(maxInvocationScriptSize)
+		if t.Version >= NEOTradingVersionPayableGAS {
+			r.readUint64() //gas
+		}
+	case EnrollmentTransaction:
+		readECPoint(r)
+	case RegisterTransaction:
+		r.readByte() //asset type
+		r.readVarBytes(maxAssetNameLength)
+		r.readUint64() //amount
+		r.readByte()   //precision
+		readECPoint(r) //owner
+		r.readBytes(Uint160Length)
+	case PublishTransaction:
+		if t.Version > 1 {
+			r.fail(fmt.Errorf("unsupported publish transaction version %v", t.Version))
+			return
+		}
+		r.readVarBytes(maxContractScriptSize)
+		r.readVarBytes(maxContractFieldLength) //parameter types
+		r.readByte()                           //return type
+		if t.Version >= 1 {
+			r.readByte() //need storage
+		}
+		for _, max := range []int{maxContractFieldLength, maxContractFieldLength, maxContractFieldLength, maxContractFieldLength, maxContractDescriptionLength} {
+			r.readVarBytes(max) //name, code version, author, email, description
+		}
+	case AgencyTransaction:
+		readAgencyExclusiveData(r)
+	case ClaimTransaction:
+		claims := readFixedLengthItems(r, transactionInputLength)
+		r.fail(checkDuplicateReferences(claims))
+	case StateTransaction:
+		count := r.readVarInt()
+		for i := uint64(0); i < count && r.err == nil; i++ {
+			r.readByte()
+			r.readVarBytes(stateDescriptorMaxKeyLength)
+			r.readVarBytes(stateDescriptorMaxFieldLength)
+			r.readVarBytes(stateDescriptorMaxValueLength)
+		}
+	default:
+		r.fail(fmt.Errorf("unsupported transaction type %v", t.Type))
+	}
+}
+
+//AntShares AgencyTransaction. [asset id(32)] + [value asset id(32)] + [agent(20)] + orders + [has split order] + split order
+//each order is [amount(8)] + [price(8)] + [client(20)] + inputs + witnesses
+func readAgencyExclusiveData(r *binaryReader) {
+	r.readBytes(32 + 32 + Uint160Length)
+	count := r.readVarInt()
+	if r.err == nil && count > maxAgencyOrders {
+		r.fail(fmt.Errorf("too many orders %v", count))
+		return
+	}
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		r.readBytes(8 + 8 + Uint160Length)
+		readFixedLengthItems(r, transactionInputLength)
+		readTransactionScripts(r)
+	}
+	if r.readByte() != 0x00 {
+		r.readBytes(8 + 8 + Uint160Length)
+	}
+}
+
+//[var int count] + N x item
+func readFixedLengthItems(r *binaryReader, itemLength int) [][]byte {
+	count := r.readVarInt()
+	if r.err != nil {
+		return nil
+	}
+	if count > uint64(r.remaining()/itemLength) {
+		r.fail(fmt.Errorf("%v items of %v bytes exceed the remaining %v bytes", count, itemLength, r.remaining()))
+		return nil
+	}
+	items := [][]byte{}
+	for i := uint64(0); i < count; i++ {
+		items = append(items, r.readBytes(itemLength))
+	}
+	return items
+}
+
+//the same [txid] + [index] twice in the inputs or the claims spends one output twice which neo rejects
+func checkDuplicateReferences(items [][]byte) error {
+	seen := map[string]bool{}
+	for _, item := range items {
+		if seen[string(item)] == true {
+			txID := hex.EncodeToString(reverseBytes(append([]byte{}, item[:32]...)))
+			return fmt.Errorf("input %v:%v is referenced more than once", txID, binary.LittleEndian.Uint16(item[32:]))
+		}
+		seen[string(item)] = true
+	}
+	return nil
+}
+
+//InvocationGas returns the gas an invocation transaction pays to run its script. version 0 doesn't have the field so it's 0
+func (t *Transaction) InvocationGas() (Fixed8, error) {
+	if t.Type != InvocationTransaction {
+		return 0, fmt.Errorf("%v has no gas", t.Type)
+	}
+	if t.Version < NEOTradingVersionPayableGAS {
+		return 0, nil
+	}
+	r := newBinaryReader(t.Data)
+	r.readVarBytes(maxInvocationScriptSize)
+	gas := Fixed8(r.readUint64())
+	if r.err != nil {
+		return 0, r.err
+	}
+	return gas, nil
+}
+
+//ParseAttributes returns the attributes of the transaction
+func (t *Transaction) ParseAttributes() ([]TransactionAttributeItem, error) {
+	return ParseTransactionAttributes(t.Attributes)
+}
+
+//Witnesses returns every invocation and verification script pair of a signed transaction
+func (t *Transaction) Witnesses() ([]TransactionValidationScript, error) {
+	return ParseTransactionScripts(t.Script)
+}
+
+func (t *Transaction) firstWitness() (*TransactionValidationScript, error) {
+	witnesses, err := t.Witnesses()
+	if err != nil {
+		return nil, err
+	}
+	if len(witnesses) == 0 {
+		return nil, fmt.Errorf("transaction is not signed")
+	}
+	return &witnesses[0], nil
+}
+
+//InvocationScript returns the invocation script of the first witness. use Witnesses when there are more signers
+func (t *Transaction) InvocationScript() ([]byte, error) {
+	witness, err := t.firstWitness()
+	if err != nil {
+		return nil, err
+	}
+	return witness.InvocationScript(), nil
+}
+
+//VerificationScript returns the verification script of the first witness. use Witnesses when there are more signers
+func (t *Transaction) VerificationScript() ([]byte, error) {
+	witness, err := t.firstWitness()
+	if err != nil {
+		return nil, err
+	}
+	return witness.VerificationScript(), nil
+}
@@ -0,0 +1,320 @@
+package smartcontract_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestDescriptionAttributeRoundTrip(t *testing.T) {
+	description := []byte(strings.Repeat("order-1234567890", 7)[:100])
+	attributes := smartcontract.TransactionAttributes{}
+	err := attributes.WithDescription(description)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = attributes.WithDescriptionURL("https://example.com/orders/1234567890")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := smartcontract.NewContractTransaction()
+	tx.Attributes, err = smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	raw := tx.ToBytes()
+
+	deserialized, err := smartcontract.DeserializeTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(deserialized.ToBytes(), raw) == false {
+		t.Fatalf("expected %x got %x", raw, deserialized.ToBytes())
+	}
+	items, err := deserialized.ParseAttributes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 attributes got %v", len(items))
+	}
+	//sorted by usage
+	if items[0].Usage != smartcontract.DescriptionUrl || string(items[0].Data) != "https://example.com/orders/1234567890" {
+		t.Fail()
+	}
+	if items[1].Usage != smartcontract.Description || bytes.Equal(items[1].Data, description) == false {
+		t.Fail()
+	}
+}
+
+func TestDescriptionAttributeTooLong(t *testing.T) {
+	attributes := smartcontract.TransactionAttributes{}
+	if attributes.WithDescriptionURL(strings.Repeat("a", 256)) == nil {
+		t.Fail()
+	}
+	if attributes.WithDescription(make([]byte, 65536)) == nil {
+		t.Fail()
+	}
+	if len(attributes) != 0 {
+		t.Fail()
+	}
+}
+
+func TestWithAttribute(t *testing.T) {
+	attributes := smartcontract.TransactionAttributes{}
+	hash := bytes.Repeat([]byte{0x01}, 32)
+	err := attributes.WithAttribute(smartcontract.Hash1, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = attributes.WithAttribute(smartcontract.Remark2, []byte("lock until 2745000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "02" + "a1" + hex.EncodeToString(hash) + "f2" + "12" + hex.EncodeToString([]byte("lock until 2745000"))
+	if hex.EncodeToString(b) != expected {
+		t.Fatalf("expected %v got %x", expected, b)
+	}
+
+	invalid := []struct {
+		usage smartcontract.TransactionAttribute
+		data  []byte
+	}{
+		{smartcontract.Hash1, bytes.Repeat([]byte{0x01}, 33)},
+		{smartcontract.Script, bytes.Repeat([]byte{0x01}, 21)},
+		{smartcontract.DescriptionUrl, bytes.Repeat([]byte{0x01}, 256)},
+		{smartcontract.Remark, bytes.Repeat([]byte{0x01}, 65536)},
+		{smartcontract.TransactionAttribute(0x10), []byte{0x01}},
+	}
+	for _, a := range invalid {
+		attributes := smartcontract.TransactionAttributes{}
+		if attributes.WithAttribute(a.usage, a.data) == nil {
+			t.Fatalf("expected an error for 0x%02x with %v bytes", byte(a.usage), len(a.data))
+		}
+		if len(attributes) != 0 {
+			t.Fatalf("invalid attribute 0x%02x must not be added", byte(a.usage))
+		}
+	}
+}
+
+func TestDeserializeTransactionTruncated(t *testing.T) {
+	//contract transaction with 1 input but the input data is cut
+	_, err := smartcontract.DeserializeTransaction([]byte{0x80, 0x00, 0x00, 0x01, 0xab})
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestDeserializeTransactionTrailingBytes(t *testing.T) {
+	tx := smartcontract.NewContractTransaction()
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	tx.SetWitnesses([]smartcontract.TransactionValidationScript{{StackScript: []byte{0x00}, RedeemScript: []byte{0x51}}})
+	raw := tx.ToBytes()
+
+	_, err := smartcontract.DeserializeTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = smartcontract.DeserializeTransaction(append(raw, 0x00))
+	if err == nil {
+		t.Fatal("expected an error for a trailing byte")
+	}
+}
+
+func TestDeserializeMinerTransaction(t *testing.T) {
+	//miner transaction of the NEO mainnet genesis block
+	raw, _ := hex.DecodeString("00001dac2b7c00000000")
+	tx, err := smartcontract.DeserializeTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type != smartcontract.MinerTransaction || hex.EncodeToString(tx.Data) != "1dac2b7c" {
+		t.Fatalf("unexpected transaction %+v", tx)
+	}
+	if tx.ToTXID() != "fb5bd72b2d6792d75dc2f1084ffa9e9f70ca85543c717a6b13d9959b452a57d6" {
+		t.Fatalf("unexpected txid %v", tx.ToTXID())
+	}
+	if hex.EncodeToString(tx.ToBytes()) != hex.EncodeToString(raw) {
+		t.Fatalf("expected %x got %x", raw, tx.ToBytes())
+	}
+}
+
+func TestECDHAttributeRoundTrip(t *testing.T) {
+	//X coordinate of 02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986
+	x, _ := hex.DecodeString("e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986")
+	attributes := map[smartcontract.TransactionAttribute][]byte{smartcontract.ECDH02: x}
+
+	b, err := smartcontract.NewScriptBuilder().GenerateTransactionAttributes(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//1 attribute, usage 0x02 then 32 bytes without a length
+	if hex.EncodeToString(b) != "0102"+hex.EncodeToString(x) {
+		t.Fatalf("unexpected attributes %x", b)
+	}
+
+	tx := smartcontract.NewContractTransaction()
+	tx.Attributes = b
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	deserialized, err := smartcontract.DeserializeTransaction(tx.ToBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := deserialized.ParseAttributes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Usage != smartcontract.ECDH02 || bytes.Equal(items[0].Data, x) == false {
+		t.Fatalf("unexpected attributes %+v", items)
+	}
+
+	_, err = smartcontract.NewScriptBuilder().GenerateTransactionAttributes(map[smartcontract.TransactionAttribute][]byte{smartcontract.ECDH03: x[:31]})
+	if err == nil {
+		t.Fatal("expected an error for a 31 bytes ECDH03 attribute")
+	}
+}
+
+func TestWitnessesRoundTrip(t *testing.T) {
+	signature := bytes.Repeat([]byte{0x01}, 64)
+	publicKey, _ := hex.DecodeString("02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986")
+	witnesses := []smartcontract.TransactionValidationScript{
+		{
+			StackScript:  append([]byte{0x40}, signature...),
+			RedeemScript: append(append([]byte{0x21}, publicKey...), byte(smartcontract.CHECKSIG)),
+		},
+		//a verification script over 252 bytes needs a 3 bytes var int length
+		{
+			StackScript:  []byte{},
+			RedeemScript: bytes.Repeat([]byte{byte(smartcontract.NOP)}, 300),
+		},
+	}
+
+	tx := smartcontract.NewContractTransaction()
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	tx.SetWitnesses(witnesses)
+
+	expected := "02" + "41" + hex.EncodeToString(witnesses[0].StackScript) + "23" + hex.EncodeToString(witnesses[0].RedeemScript) +
+		"00" + "fd2c01" + hex.EncodeToString(witnesses[1].RedeemScript)
+	if hex.EncodeToString(tx.Script) != expected {
+		t.Fatalf("expected %v got %x", expected, tx.Script)
+	}
+
+	deserialized, err := smartcontract.DeserializeTransaction(tx.ToBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := deserialized.Witnesses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != len(witnesses) {
+		t.Fatalf("expected %v witnesses got %v", len(witnesses), len(parsed))
+	}
+	for i := range witnesses {
+		if bytes.Equal(parsed[i].InvocationScript(), witnesses[i].InvocationScript()) == false ||
+			bytes.Equal(parsed[i].VerificationScript(), witnesses[i].VerificationScript()) == false {
+			t.Fatalf("witness %v expected %+v got %+v", i, witnesses[i], parsed[i])
+		}
+	}
+
+	//no witness is still a count
+	tx.SetWitnesses(nil)
+	if hex.EncodeToString(tx.Script) != "00" {
+		t.Fatalf("expected 00 got %x", tx.Script)
+	}
+}
+
+func TestContractTransactionSigningData(t *testing.T) {
+	//ContractTransaction from the getrawtransaction example of the NEO docs. txid f4250dab094c38d8265acc15c366dc508d2e14bf5699e12d9df26577ed74d657
+	unsigned := "80000001195876cb34364dc38b730077156c6bc3a7fc570044a66fbfeeea56f71327e8ab0000029b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc500c65eaf440000000f9a23e06f74cf86b8827a9108ec2e0f89ad956c9b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc50092e14b5e00000030aab52ad93f6ce17ca07fa88fc191828c58cb71"
+	witness := "014140915467ecd359684b2dc358024ca750609591aa731a0b309c7fb3cab5cd0836ad3992aa0a24da431f43b68883ea5651d548feb6bd3c8e16376e6e426f91f84c58232103322f35c7819267e721335948d385fae5be66e7ba8c748ac15467dcca0693692dac"
+	raw, _ := hex.DecodeString(unsigned + witness)
+
+	tx, err := smartcontract.DeserializeTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := smartcontract.ContractTransactionSigningData(tx.Attributes, tx.Inputs, tx.Outputs)
+	if hex.EncodeToString(data) != unsigned {
+		t.Fatalf("expected %v got %x", unsigned, data)
+	}
+
+	//the txid neo-cli reports is hash256 of the same bytes
+	hash := sha256.Sum256(data)
+	hash = sha256.Sum256(hash[:])
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	if hex.EncodeToString(hash[:]) != "f4250dab094c38d8265acc15c366dc508d2e14bf5699e12d9df26577ed74d657" {
+		t.Fatalf("unexpected txid %x", hash)
+	}
+
+	//and the signature neo-cli made verifies against sha256 of it
+	signingHash := sha256.Sum256(data)
+	if hex.EncodeToString(tx.SigningHash()) != hex.EncodeToString(signingHash[:]) {
+		t.Fatalf("expected signing hash %x got %x", signingHash, tx.SigningHash())
+	}
+	err = smartcontract.VerifyTransactionSignature(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeserializePublishTransaction(t *testing.T) {
+	fields := "04" + hex.EncodeToString([]byte("name")) + "0131" + "0161" + "0165" + "0164" //name, code version, author, email, description
+	//script + parameter types String and Array + return type ByteArray
+	contract := "0151" + "020710" + "05"
+	for _, c := range []struct {
+		version string
+		data    string
+	}{
+		{"00", contract + fields},
+		//version 1 has need storage
+		{"01", contract + "01" + fields},
+	} {
+		raw, _ := hex.DecodeString("d0" + c.version + c.data + "000000")
+		tx, err := smartcontract.DeserializeTransaction(raw)
+		if err != nil {
+			t.Fatalf("version %v: %v", c.version, err)
+		}
+		if hex.EncodeToString(tx.Data) != c.data || bytes.Equal(tx.ToBytes(), raw) == false {
+			t.Fatalf("version %v: unexpected data %x", c.version, tx.Data)
+		}
+	}
+
+	raw, _ := hex.DecodeString("d002" + contract + "01" + fields + "000000")
+	_, err := smartcontract.DeserializeTransaction(raw)
+	if err == nil {
+		t.Fatal("expected an error for publish transaction version 2")
+	}
+}
+
+func TestDeserializeAgencyTransaction(t *testing.T) {
+	order := "00e1f50500000000" + "00e1f50500000000" + strings.Repeat("11", 20) + "00" + "00" //amount, price, client, no input, no witness
+	data := string(smartcontract.NEO) + string(smartcontract.GAS) + strings.Repeat("22", 20) + "01" + order +
+		"01" + "00e1f50500000000" + "00e1f50500000000" + strings.Repeat("33", 20) //split order
+	raw, _ := hex.DecodeString("b000" + data + "000000")
+	tx, err := smartcontract.DeserializeTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(tx.Data) != data {
+		t.Fatalf("unexpected data %x", tx.Data)
+	}
+}
@@ -0,0 +1,38 @@
+package smartcontract
+
+import (
+	"fmt"
+
+	"github.com/o3labs/neo-utils/neoutils/btckey"
+)
+
+//GenerateEnrollmentTransactionData returns the exclusive data of an EnrollmentTransaction
+//which is the compressed public key of the candidate [0x02 or 0x03] + [x(32)].
+//neo 2.x nodes no longer accept EnrollmentTransaction. a validator registers with NewValidatorRegisteredDescriptor in a StateTransaction
+//https://github.com/neo-project/neo/blob/master-2.x/neo/Network/P2P/Payloads/EnrollmentTransaction.cs
+func GenerateEnrollmentTransactionData(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != publicKeyLength || (publicKey[0] != 0x02 && publicKey[0] != 0x03) {
+		return nil, fmt.Errorf("invalid compressed public key %x", publicKey)
+	}
+	pub := btckey.PublicKey{}
+	err := pub.FromBytes(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, publicKey...), nil
+}
+
+//ECPoint the way neo serializes it. 0x00 is the point at infinity
+func readECPoint(r *binaryReader) []byte {
+	start := r.offset
+	switch prefix := r.readByte(); prefix {
+	case 0x00:
+	case 0x02, 0x03:
+		r.readBytes(32)
+	case 0x04:
+		r.readBytes(64)
+	default:
+		r.fail(fmt.Errorf("invalid public key prefix 0x%02x", prefix))
+	}
+	return r.readSince(start)
+}
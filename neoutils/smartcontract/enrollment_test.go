@@ -0,0 +1,47 @@
+package smartcontract_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestEnrollmentTransaction(t *testing.T) {
+	publicKey, _ := hex.DecodeString("02486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a70")
+	data, err := smartcontract.GenerateEnrollmentTransactionData(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := smartcontract.NewEnrollmentTransaction()
+	tx.Data = data
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	//[type] + [version] + [public key] + empty attributes, inputs and outputs
+	expected := "20" + "00" + "02486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a70" + "000000"
+	if hex.EncodeToString(tx.ToBytes()) != expected {
+		t.Fatalf("expected %v got %x", expected, tx.ToBytes())
+	}
+
+	parsed, err := smartcontract.DeserializeTransaction(tx.ToBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(parsed.Data) != hex.EncodeToString(publicKey) {
+		t.Fatalf("expected exclusive data %x got %x", publicKey, parsed.Data)
+	}
+
+	//wrong prefix, too short and not on the curve
+	for _, invalid := range []string{
+		"04486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a70",
+		"02486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a7",
+		"02ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	} {
+		b, _ := hex.DecodeString(invalid)
+		_, err := smartcontract.GenerateEnrollmentTransactionData(b)
+		if err == nil {
+			t.Fatalf("expected an error for %v", invalid)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package smartcontract
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//Fixed8 is an amount with 8 decimals stored as an integer, the same way it's serialized in a transaction. 1 GAS is 100000000
+//https://github.com/neo-project/neo/blob/master/neo/Fixed8.cs
+type Fixed8 int64
+
+const fixed8Decimals = 100000000
+
+//NewFixed8 converts a float amount rounded to 8 decimals
+func NewFixed8(value float64) Fixed8 {
+	return Fixed8(math.Round(value * fixed8Decimals))
+}
+
+//ParseFixed8 parses a decimal string e.g. "1.5" exactly without going through float64
+func ParseFixed8(s string) (Fixed8, error) {
+	negative := strings.HasPrefix(s, "-")
+	parts := strings.SplitN(strings.TrimPrefix(s, "-"), ".", 2)
+	if len(parts[0]) == 0 {
+		return 0, fmt.Errorf("invalid amount %v", s)
+	}
+	integer, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %v", s)
+	}
+	fraction := int64(0)
+	if len(parts) == 2 {
+		if len(parts[1]) == 0 || len(parts[1]) > 8 {
+			return 0, fmt.Errorf("invalid amount %v. an amount has at most 8 decimals", s)
+		}
+		fraction, err = strconv.ParseInt(parts[1]+strings.Repeat("0", 8-len(parts[1])), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %v", s)
+		}
+	}
+	if integer > (math.MaxInt64-fraction)/fixed8Decimals {
+		return 0, fmt.Errorf("amount %v is too large", s)
+	}
+	value := integer*fixed8Decimals + fraction
+	if negative {
+		value = -value
+	}
+	return Fixed8(value), nil
+}
+
+func (f Fixed8) Add(o Fixed8) Fixed8 {
+	return f + o
+}
+
+func (f Fixed8) Sub(o Fixed8) Fixed8 {
+	return f - o
+}
+
+//Mul multiplies two amounts and rounds the result to 8 decimals
+func (f Fixed8) Mul(o Fixed8) Fixed8 {
+	product := new(big.Int).Mul(big.NewInt(int64(f)), big.NewInt(int64(o)))
+	half := big.NewInt(fixed8Decimals / 2)
+	if product.Sign() < 0 {
+		half.Neg(half)
+	}
+	product.Add(product, half)
+	product.Quo(product, big.NewInt(fixed8Decimals))
+	return Fixed8(product.Int64())
+}
+
+func (f Fixed8) Float64() float64 {
+	return float64(f) / fixed8Decimals
+}
+
+//String returns the amount in decimal without trailing zeros e.g. 1.5 and 10
+func (f Fixed8) String() string {
+	sign := ""
+	value := uint64(f)
+	if f < 0 {
+		sign = "-"
+		value = uint64(-f)
+	}
+	integer := value / fixed8Decimals
+	fraction := value % fixed8Decimals
+	if fraction == 0 {
+		return fmt.Sprintf("%v%v", sign, integer)
+	}
+	return fmt.Sprintf("%v%v.%v", sign, integer, strings.TrimRight(fmt.Sprintf("%08d", fraction), "0"))
+}
@@ -0,0 +1,62 @@
+package smartcontract_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestFixed8Arithmetic(t *testing.T) {
+	a := smartcontract.NewFixed8(0.1)
+	b := smartcontract.NewFixed8(0.7)
+	if a.Add(b) != smartcontract.NewFixed8(0.8) {
+		t.Fatalf("0.1 + 0.7 expected 0.8 got %v", a.Add(b))
+	}
+	if b.Sub(a) != 60000000 {
+		t.Fatalf("0.7 - 0.1 expected 0.6 got %v", b.Sub(a))
+	}
+	if a.Sub(b).String() != "-0.6" {
+		t.Fatalf("0.1 - 0.7 expected -0.6 got %v", a.Sub(b))
+	}
+	if smartcontract.NewFixed8(1.5).Mul(smartcontract.NewFixed8(3)) != smartcontract.NewFixed8(4.5) {
+		t.Fatalf("1.5 x 3 expected 4.5 got %v", smartcontract.NewFixed8(1.5).Mul(smartcontract.NewFixed8(3)))
+	}
+	//0.00000001 x 0.5 rounds to 0.00000001
+	if smartcontract.Fixed8(1).Mul(smartcontract.NewFixed8(0.5)) != 1 {
+		t.Fail()
+	}
+	//larger than int64 before dividing
+	large := smartcontract.NewFixed8(100000000)
+	if large.Mul(smartcontract.NewFixed8(2)) != smartcontract.NewFixed8(200000000) {
+		t.Fatalf("expected 200000000 got %v", large.Mul(smartcontract.NewFixed8(2)))
+	}
+	if smartcontract.NewFixed8(1.5).Float64() != 1.5 {
+		t.Fail()
+	}
+}
+
+func TestFixed8String(t *testing.T) {
+	cases := map[smartcontract.Fixed8]string{
+		0:          "0",
+		1:          "0.00000001",
+		100000000:  "1",
+		150000000:  "1.5",
+		1000000000: "10",
+		123456789:  "1.23456789",
+		-50000000:  "-0.5",
+	}
+	for value, expected := range cases {
+		if value.String() != expected {
+			t.Fatalf("expected %v got %v", expected, value.String())
+		}
+		parsed, err := smartcontract.ParseFixed8(expected)
+		if err != nil || parsed != value {
+			t.Fatalf("parse %v expected %d got %d %v", expected, value, parsed, err)
+		}
+	}
+	for _, invalid := range []string{"", "1.", ".5", "1.123456789", "abc", "1.5x"} {
+		if _, err := smartcontract.ParseFixed8(invalid); err == nil {
+			t.Fatalf("expected an error parsing %v", invalid)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package smartcontract
+
+import (
+	"fmt"
+)
+
+//each unit of the opcode price is 0.001 GAS
+const gasPerPriceUnit = Fixed8(100000)
+
+//gas an invocation can use without paying a system fee
+const freeExecutionGas = Fixed8(10 * 100000000)
+
+//price of the interop services with a fixed price. the rest cost 1
+//https://github.com/neo-project/neo/blob/master-2.x/neo/SmartContract/ApplicationEngine.cs
+var sysCallPrices = map[string]int64{
+	"System.Runtime.CheckWitness":            200,
+	"Neo.Runtime.CheckWitness":               200,
+	"AntShares.Runtime.CheckWitness":         200,
+	"System.Blockchain.GetHeader":            100,
+	"Neo.Blockchain.GetHeader":               100,
+	"AntShares.Blockchain.GetHeader":         100,
+	"System.Blockchain.GetBlock":             200,
+	"Neo.Blockchain.GetBlock":                200,
+	"AntShares.Blockchain.GetBlock":          200,
+	"System.Blockchain.GetTransaction":       100,
+	"Neo.Blockchain.GetTransaction":          100,
+	"AntShares.Blockchain.GetTransaction":    100,
+	"System.Blockchain.GetTransactionHeight": 100,
+	"Neo.Blockchain.GetTransactionHeight":    100,
+	"Neo.Blockchain.GetAccount":              100,
+	"AntShares.Blockchain.GetAccount":        100,
+	"Neo.Blockchain.GetValidators":           200,
+	"AntShares.Blockchain.GetValidators":     200,
+	"Neo.Blockchain.GetAsset":                100,
+	"AntShares.Blockchain.GetAsset":          100,
+	"System.Blockchain.GetContract":          100,
+	"Neo.Blockchain.GetContract":             100,
+	"AntShares.Blockchain.GetContract":       100,
+	"Neo.Transaction.GetReferences":          200,
+	"AntShares.Transaction.GetReferences":    200,
+	"Neo.Transaction.GetUnspentCoins":        200,
+	"Neo.Transaction.GetWitnesses":           200,
+	"Neo.Witness.GetVerificationScript":      100,
+	"Neo.Account.IsStandard":                 100,
+	"Neo.Asset.Create":                       5000 * 100000000 / int64(gasPerPriceUnit),
+	"AntShares.Asset.Create":                 5000 * 100000000 / int64(gasPerPriceUnit),
+	"System.Storage.Get":                     100,
+	"Neo.Storage.Get":                        100,
+	"AntShares.Storage.Get":                  100,
+	"System.Storage.Delete":                  100,
+	"Neo.Storage.Delete":                     100,
+	"AntShares.Storage.Delete":               100,
+}
+
+//the price of these depends on the values on the stack when they run so it can't be known from the script
+var dynamicSysCalls = map[string]bool{
+	"System.Storage.Put":         true,
+	"System.Storage.PutEx":       true,
+	"Neo.Storage.Put":            true,
+	"AntShares.Storage.Put":      true,
+	"Neo.Asset.Renew":            true,
+	"AntShares.Asset.Renew":      true,
+	"Neo.Contract.Create":        true,
+	"Neo.Contract.Migrate":       true,
+	"AntShares.Contract.Create":  true,
+	"AntShares.Contract.Migrate": true,
+}
+
+func sysCallPrice(name string) (int64, error) {
+	if dynamicSysCalls[name] {
+		return 0, fmt.Errorf("price of %v depends on the data it's called with", name)
+	}
+	price, ok := sysCallPrices[name]
+	if !ok {
+		return 1, nil
+	}
+	return price, nil
+}
+
+//number pushed by PUSH1-PUSH16
+func pushedSmallInt(i instruction) (int64, bool) {
+	if i.OpCode >= PUSH1 && i.OpCode <= PUSH16 {
+		return int64(i.OpCode-PUSH1) + 1, true
+	}
+	return 0, false
+}
+
+//ScriptGasCost returns the GAS the VM charges to run every instruction of the script once the same way ApplicationEngine prices them.
+//this is the cost of the script itself. the code of a contract called with APPCALL is not included
+//so for a script that calls a contract it's only a lower bound. jumps are not followed so a loop is counted once.
+//CHECKMULTISIG must be right after the push of the number of public keys and SYSCALLs priced by their data e.g. Storage.Put are an error.
+//the first 10 GAS of an invocation are free. this doesn't take it off
+func ScriptGasCost(script []byte) (float64, error) {
+	instructions, err := readInstructions(script)
+	if err != nil {
+		return 0, err
+	}
+	total := int64(0)
+	for index, i := range instructions {
+		price := int64(1)
+		switch {
+		case i.OpCode <= PUSH16, i.OpCode == NOP:
+			price = 0
+		case i.OpCode == APPCALL, i.OpCode == TAILCALL, i.OpCode == SHA1, i.OpCode == SHA256:
+			price = 10
+		case i.OpCode == HASH160, i.OpCode == HASH256:
+			price = 20
+		case i.OpCode == CHECKSIG, i.OpCode == VERIFY:
+			price = 100
+		case i.OpCode == CHECKMULTISIG:
+			if index == 0 {
+				return 0, fmt.Errorf("number of public keys of CHECKMULTISIG at %v is unknown", i.Offset)
+			}
+			n, ok := pushedSmallInt(instructions[index-1])
+			if !ok {
+				return 0, fmt.Errorf("number of public keys of CHECKMULTISIG at %v is unknown", i.Offset)
+			}
+			price = 100 * n
+		case i.OpCode == SYSCALL:
+			price, err = sysCallPrice(string(i.Operand))
+			if err != nil {
+				return 0, err
+			}
+		}
+		total += price
+	}
+	return (Fixed8(total) * gasPerPriceUnit).Float64(), nil
+}
+
+//IsFreeExecution tells if the script costs no more than the 10 GAS every invocation gets for free
+//so the invocation transaction doesn't need gas. the cost is ScriptGasCost with the same limits.
+//a script with APPCALL or TAILCALL is an error because the code of the contract it calls isn't counted.
+//ask the node with EstimateSystemFee (invokescript) for those e.g. a NEP-5 transfer
+func IsFreeExecution(script []byte) (bool, error) {
+	instructions, err := readInstructions(script)
+	if err != nil {
+		return false, err
+	}
+	for _, i := range instructions {
+		if i.OpCode == APPCALL || i.OpCode == TAILCALL {
+			return false, fmt.Errorf("script calls a contract at %v. its cost can only be known by running it with invokescript", i.Offset)
+		}
+	}
+	cost, err := ScriptGasCost(script)
+	if err != nil {
+		return false, err
+	}
+	return NewFixed8(cost) <= freeExecutionGas, nil
+}
@@ -0,0 +1,101 @@
+package smartcontract_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestScriptGasCost(t *testing.T) {
+	from := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	publicKey := "02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986"
+	checkWitness := "14" + hex.EncodeToString(from) + "68" + "184e656f2e52756e74696d652e436865636b5769746e657373"
+
+	//prices from ApplicationEngine.GetPrice and GetPriceForSysCall
+	cases := []struct {
+		script   string
+		expected float64
+	}{
+		//pushes are free and PACK is 1
+		{"0000c1", 0.001},
+		//single signature verification script
+		{"21" + publicKey + "ac", 0.1},
+		//2 of 3 multisig costs 100 for each public key
+		{"52" + "21" + publicKey + "21" + publicKey + "21" + publicKey + "53" + "ae", 0.3},
+		//Neo.Runtime.CheckWitness
+		{checkWitness, 0.2},
+		//HASH160 and SHA256
+		{"00a9a8", 0.03},
+		//VERIFY costs the same as CHECKSIG
+		{"000000ad", 0.1},
+	}
+	for _, c := range cases {
+		script, _ := hex.DecodeString(c.script)
+		cost, err := smartcontract.ScriptGasCost(script)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != c.expected {
+			t.Fatalf("expected %v got %v for %v", c.expected, cost, c.script)
+		}
+	}
+
+	invalid := []string{
+		//Neo.Storage.Put is priced by the size of the value
+		"680f4e656f2e53746f726167652e507574",
+		//number of public keys is not pushed right before CHECKMULTISIG
+		"ae",
+		//PUSHBYTES2 with one byte
+		"02ab",
+	}
+	for _, s := range invalid {
+		script, _ := hex.DecodeString(s)
+		_, err := smartcontract.ScriptGasCost(script)
+		if err == nil {
+			t.Fatalf("expected an error for %v", s)
+		}
+	}
+}
+
+func TestIsFreeExecution(t *testing.T) {
+	scriptHash, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	from := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	to := smartcontract.ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+	transfer := smartcontract.NewScriptBuilder().GenerateContractInvocationScript(scriptHash, "transfer", []interface{}{from, to, 100000000})
+	//the contract code a NEP-5 transfer runs isn't in the script
+	_, err := smartcontract.IsFreeExecution(transfer)
+	if err == nil {
+		t.Fatal("expected an error for a script that calls a contract")
+	}
+	cost, err := smartcontract.ScriptGasCost(transfer)
+	if err != nil || cost <= 0 {
+		t.Fatalf("expected the cost of the script alone got %v %v", cost, err)
+	}
+
+	//Neo.Asset.Create costs 5000 GAS
+	create, _ := hex.DecodeString("68104e656f2e41737365742e437265617465")
+	free, err := smartcontract.IsFreeExecution(create)
+	if err != nil || free == true {
+		t.Fatalf("expected Neo.Asset.Create not to be free got %v %v", free, err)
+	}
+
+	//100 CHECKSIG are exactly 10 GAS and one more goes over
+	script := []byte{}
+	for i := 0; i < 100; i++ {
+		script = append(script, byte(smartcontract.CHECKSIG))
+	}
+	free, err = smartcontract.IsFreeExecution(script)
+	if err != nil || free == false {
+		t.Fatalf("expected 10 GAS to be free got %v %v", free, err)
+	}
+	free, err = smartcontract.IsFreeExecution(append(script, byte(smartcontract.CHECKSIG)))
+	if err != nil || free == true {
+		t.Fatalf("expected 10.1 GAS not to be free got %v %v", free, err)
+	}
+
+	_, err = smartcontract.IsFreeExecution([]byte{0x02, 0xab})
+	if err == nil {
+		t.Fatal("expected an error for an invalid script")
+	}
+}
@@ -0,0 +1,78 @@
+package smartcontract
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+//Hash160 is a script hash (UInt160) stored in little endian, the order it is pushed to contracts
+type Hash160 [Uint160Length]byte
+
+//Hash256 is a transaction or block hash (UInt256) stored in little endian, the order it is pushed to contracts
+type Hash256 [32]byte
+
+//decodes a big endian hex string like the ones shown by neo-cli and explorers and returns it in little endian
+func decodeBigEndianHash(hexString string, length int) ([]byte, error) {
+	trimmed0x := hexString
+	if has0xPrefix(hexString) == true {
+		trimmed0x = hexString[2:]
+	}
+	b, err := hex.DecodeString(trimmed0x)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != length {
+		return nil, fmt.Errorf("hash must be %v bytes but got %v", length, len(b))
+	}
+	return reverseBytes(b), nil
+}
+
+//NewHash160 parses a big endian script hash e.g. 0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9
+func NewHash160(hexString string) (Hash160, error) {
+	h := Hash160{}
+	b, err := decodeBigEndianHash(hexString, Uint160Length)
+	if err != nil {
+		return h, err
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+//NewHash256 parses a big endian transaction or block hash
+func NewHash256(hexString string) (Hash256, error) {
+	h := Hash256{}
+	b, err := decodeBigEndianHash(hexString, len(h))
+	if err != nil {
+		return h, err
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+//Hash160FromScriptHash converts a little endian ScriptHash e.g. from NewScriptHash
+func Hash160FromScriptHash(scriptHash ScriptHash) (Hash160, error) {
+	h := Hash160{}
+	if len(scriptHash) != Uint160Length {
+		return h, fmt.Errorf("hash must be %v bytes but got %v", Uint160Length, len(scriptHash))
+	}
+	copy(h[:], scriptHash)
+	return h, nil
+}
+
+func (h Hash160) ToLittleEndianBytes() []byte {
+	return append([]byte{}, h[:]...)
+}
+
+//String returns the big endian hex without 0x prefix
+func (h Hash160) String() string {
+	return hex.EncodeToString(reverseBytes(h.ToLittleEndianBytes()))
+}
+
+func (h Hash256) ToLittleEndianBytes() []byte {
+	return append([]byte{}, h[:]...)
+}
+
+//String returns the big endian hex without 0x prefix
+func (h Hash256) String() string {
+	return hex.EncodeToString(reverseBytes(h.ToLittleEndianBytes()))
+}
@@ -0,0 +1,63 @@
+package smartcontract_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestPushHash160(t *testing.T) {
+	h, err := smartcontract.NewHash160("0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.String() != "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9" {
+		t.Fatalf("expected big endian string got %v", h.String())
+	}
+	sb := smartcontract.NewScriptBuilder()
+	sb.Push(h)
+	b := sb.ToBytes()
+	if len(b) != 21 || b[0] != 0x14 {
+		t.Fatalf("expected PUSHBYTES20 and 20 bytes got %x", b)
+	}
+	//little endian
+	expected := "14f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec"
+	if sb.FullHexString() != expected {
+		t.Fatalf("expected %v got %v", expected, sb.FullHexString())
+	}
+
+	//same bytes as the ScriptHash from NewScriptHash
+	scriptHash, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	fromScriptHash, err := smartcontract.Hash160FromScriptHash(scriptHash)
+	if err != nil || fromScriptHash != h {
+		t.Fatalf("expected %v got %v %v", h, fromScriptHash, err)
+	}
+}
+
+func TestPushHash256(t *testing.T) {
+	h, err := smartcontract.NewHash256("c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb := smartcontract.NewScriptBuilder()
+	sb.Push(smartcontract.ContractParameter{Type: smartcontract.Hash256Type, Value: h})
+	b := sb.ToBytes()
+	if len(b) != 33 || b[0] != 0x20 {
+		t.Fatalf("expected PUSHBYTES32 and 32 bytes got %x", b)
+	}
+	expected := "209b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc5"
+	if sb.FullHexString() != expected {
+		t.Fatalf("expected %v got %v", expected, sb.FullHexString())
+	}
+}
+
+func TestNewHashInvalidLength(t *testing.T) {
+	_, err := smartcontract.NewHash160("c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+	if err == nil {
+		t.Fatal("expected an error for a 32 bytes Hash160")
+	}
+	_, err = smartcontract.NewHash256("0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	if err == nil {
+		t.Fatal("expected an error for a 20 bytes Hash256")
+	}
+}
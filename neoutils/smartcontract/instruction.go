@@ -0,0 +1,53 @@
+package smartcontract
+
+import (
+	"fmt"
+)
+
+//instruction is an opcode with the data that follows it in the script
+type instruction struct {
+	Offset  int
+	OpCode  OpCode
+	Operand []byte
+}
+
+//maximum length of a SYSCALL method name
+const maxSysCallNameLength = 252
+
+//readInstructions splits a script into instructions the same way the VM reads it
+//PUSHBYTES and PUSHDATA carry the pushed data, jumps and CALL a 2 bytes offset, APPCALL and TAILCALL a 20 bytes script hash
+//and SYSCALL the method name prefixed with a var int length
+func readInstructions(script []byte) ([]instruction, error) {
+	r := newBinaryReader(script)
+	instructions := []instruction{}
+	for r.remaining() > 0 {
+		offset := r.offset
+		op := OpCode(r.readByte())
+		var operand []byte
+		switch {
+		case op >= PUSHBYTES1 && op <= PUSHBYTES75:
+			operand = r.readBytes(int(op))
+		case op == PUSHDATA1:
+			operand = r.readBytes(int(r.readByte()))
+		case op == PUSHDATA2:
+			operand = r.readBytes(int(r.readUint16()))
+		case op == PUSHDATA4:
+			length := r.readUint32()
+			if uint64(length) > uint64(r.remaining()) {
+				r.fail(fmt.Errorf("unexpected end of data at %v reading %v bytes", r.offset, length))
+			}
+			operand = r.readBytes(int(length))
+		case op == JMP || op == JMPIF || op == JMPIFNOT || op == CALL:
+			operand = r.readBytes(2)
+		case op == APPCALL || op == TAILCALL:
+			operand = r.readBytes(Uint160Length)
+		case op == SYSCALL:
+			operand = r.readVarBytes(maxSysCallNameLength)
+		}
+		if r.err != nil {
+			return nil, fmt.Errorf("invalid instruction 0x%02x at %v: %v", byte(op), offset, r.err)
+		}
+		instructions = append(instructions, instruction{Offset: offset, OpCode: op, Operand: operand})
+	}
+	return instructions, nil
+}
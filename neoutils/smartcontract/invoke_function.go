@@ -0,0 +1,182 @@
+package smartcontract
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+var contractParameterTypeNames = map[ContractParameterType]string{
+	SignatureType: "Signature",
+	BooleanType:   "Boolean",
+	IntegerType:   "Integer",
+	Hash160Type:   "Hash160",
+	Hash256Type:   "Hash256",
+	ByteArrayType: "ByteArray",
+	PublicKeyType: "PublicKey",
+	StringType:    "String",
+	ArrayType:     "Array",
+	MapType:       "Map",
+}
+
+//String returns the name of the type used by the RPC and the contract ABI e.g. Hash160
+func (t ContractParameterType) String() string {
+	name, ok := contractParameterTypeNames[t]
+	if !ok {
+		return fmt.Sprintf("0x%02x", byte(t))
+	}
+	return name
+}
+
+//InvokeFunctionParameter is a parameter in the JSON format the invokefunction RPC method reads
+//e.g. {"type":"Hash160","value":"ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"}
+type InvokeFunctionParameter struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type invokeFunctionPair struct {
+	Key   InvokeFunctionParameter `json:"key"`
+	Value InvokeFunctionParameter `json:"value"`
+}
+
+func fixedLengthHex(value interface{}, length int, name string) (string, error) {
+	b, ok := value.([]byte)
+	if !ok || len(b) != length {
+		return "", fmt.Errorf("%v parameter must be %v bytes", name, length)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+//RPC nodes read Hash160 and Hash256 in big endian, the same as explorers show them
+func bigEndianHex(littleEndian []byte) string {
+	return hex.EncodeToString(reverseBytes(append([]byte{}, littleEndian...)))
+}
+
+func fixedLengthBigEndianHex(littleEndian []byte, length int, name string) (string, error) {
+	if len(littleEndian) != length {
+		return "", fmt.Errorf("%v parameter must be %v bytes", name, length)
+	}
+	return bigEndianHex(littleEndian), nil
+}
+
+//InvokeFunctionParameter converts the parameter to the JSON format of the invokefunction RPC method.
+//it accepts the same values as pushing the parameter to a script
+func (p ContractParameter) InvokeFunctionParameter() (InvokeFunctionParameter, error) {
+	param := InvokeFunctionParameter{Type: p.Type.String()}
+	var err error
+	switch p.Type {
+	case SignatureType:
+		param.Value, err = fixedLengthHex(p.Value, 64, "Signature")
+	case BooleanType:
+		v, ok := p.Value.(bool)
+		if !ok {
+			return param, fmt.Errorf("Boolean parameter must be bool")
+		}
+		param.Value = v
+	case IntegerType:
+		//integers are strings so values over 53 bits survive JSON
+		switch v := p.Value.(type) {
+		case int:
+			param.Value = fmt.Sprintf("%d", v)
+		case int64:
+			param.Value = fmt.Sprintf("%d", v)
+		case *big.Int:
+			param.Value = v.String()
+		default:
+			return param, fmt.Errorf("Integer parameter must be int, int64 or *big.Int")
+		}
+	case Hash160Type:
+		switch v := p.Value.(type) {
+		case Hash160:
+			param.Value = v.String()
+		case NEOAddress:
+			param.Value, err = fixedLengthBigEndianHex(v, Uint160Length, "Hash160")
+		case ScriptHash:
+			param.Value, err = fixedLengthBigEndianHex(v, Uint160Length, "Hash160")
+		case []byte:
+			param.Value, err = fixedLengthBigEndianHex(v, Uint160Length, "Hash160")
+		default:
+			return param, fmt.Errorf("Hash160 parameter must be %v bytes", Uint160Length)
+		}
+	case Hash256Type:
+		switch v := p.Value.(type) {
+		case Hash256:
+			param.Value = v.String()
+		case []byte:
+			param.Value, err = fixedLengthBigEndianHex(v, 32, "Hash256")
+		default:
+			return param, fmt.Errorf("Hash256 parameter must be 32 bytes")
+		}
+	case ByteArrayType:
+		v, ok := p.Value.([]byte)
+		if !ok {
+			return param, fmt.Errorf("ByteArray parameter must be []byte")
+		}
+		param.Value = hex.EncodeToString(v)
+	case PublicKeyType:
+		param.Value, err = fixedLengthHex(p.Value, publicKeyLength, "PublicKey")
+	case StringType:
+		v, ok := p.Value.(string)
+		if !ok {
+			return param, fmt.Errorf("String parameter must be string")
+		}
+		param.Value = v
+	case ArrayType:
+		v, ok := p.Value.([]ContractParameter)
+		if !ok {
+			return param, fmt.Errorf("Array parameter must be []ContractParameter")
+		}
+		items, err := invokeFunctionParameters(v)
+		if err != nil {
+			return param, err
+		}
+		param.Value = items
+	case MapType:
+		v, ok := p.Value.([]ContractParameterPair)
+		if !ok {
+			return param, fmt.Errorf("Map parameter must be []ContractParameterPair")
+		}
+		pairs := []invokeFunctionPair{}
+		for _, pair := range v {
+			key, err := pair.Key.InvokeFunctionParameter()
+			if err != nil {
+				return param, err
+			}
+			value, err := pair.Value.InvokeFunctionParameter()
+			if err != nil {
+				return param, err
+			}
+			pairs = append(pairs, invokeFunctionPair{Key: key, Value: value})
+		}
+		param.Value = pairs
+	default:
+		return param, fmt.Errorf("unsupported contract parameter type 0x%02x", byte(p.Type))
+	}
+	return param, err
+}
+
+func invokeFunctionParameters(params []ContractParameter) ([]InvokeFunctionParameter, error) {
+	list := []InvokeFunctionParameter{}
+	for _, p := range params {
+		param, err := p.InvokeFunctionParameter()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, param)
+	}
+	return list, nil
+}
+
+//InvokeFunctionParams returns the params of the invokefunction RPC method
+//[script hash in big endian, operation, [parameters]]
+func InvokeFunctionParams(scriptHash ScriptHash, operation string, params []ContractParameter) ([]interface{}, error) {
+	if len(scriptHash) != Uint160Length {
+		return nil, fmt.Errorf("script hash must be %v bytes but got %v", Uint160Length, len(scriptHash))
+	}
+	list, err := invokeFunctionParameters(params)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{bigEndianHex(scriptHash), operation, list}, nil
+}
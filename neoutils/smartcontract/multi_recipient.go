@@ -0,0 +1,54 @@
+package smartcontract
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+//outputs of the recipients sorted by script hash. a map is iterated in a random order
+//so without sorting the same recipients would give a different txid every time
+func sortedRecipientOutputs(recipients map[string]float64, assetToSend NativeAsset) ([]TransactionOutput, Fixed8, error) {
+	outputs := []TransactionOutput{}
+	sum := Fixed8(0)
+	for address, amount := range recipients {
+		receiver, err := ParseNEOAddressWithError(address)
+		if err != nil {
+			return nil, 0, err
+		}
+		if amount <= 0 {
+			return nil, 0, fmt.Errorf("amount to %v must be more than 0", address)
+		}
+		outputs = append(outputs, TransactionOutput{Asset: assetToSend, Value: NewFixed8(amount), Address: receiver})
+		sum = sum.Add(NewFixed8(amount))
+	}
+	sort.Slice(outputs, func(i, j int) bool { return bytes.Compare(outputs[i].Address, outputs[j].Address) < 0 })
+	return outputs, sum, nil
+}
+
+//GenerateMultiRecipientTransactionInput selects the inputs that pay every amount in recipients and the network fee.
+//it's GenerateTransactionInput with the sum of the amounts
+func (s *ScriptBuilder) GenerateMultiRecipientTransactionInput(recipients map[string]float64, unspent Unspent, assetToSend NativeAsset, networkFeeAmount NetworkFeeAmount) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipient")
+	}
+	_, amount, err := sortedRecipientOutputs(recipients, assetToSend)
+	if err != nil {
+		return nil, err
+	}
+	return s.GenerateTransactionInput(unspent, assetToSend, amount.Float64(), networkFeeAmount)
+}
+
+//GenerateMultiRecipientTransactionOutput sends assetToSend to every address in recipients with one output each.
+//outputs are sorted by the script hash of the address so the same recipients always give the same transaction.
+//the inputs are GenerateMultiRecipientTransactionInput with the same recipients
+func (s *ScriptBuilder) GenerateMultiRecipientTransactionOutput(sender NEOAddress, recipients map[string]float64, unspent Unspent, assetToSend NativeAsset, networkFeeAmount NetworkFeeAmount) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipient")
+	}
+	list, _, err := sortedRecipientOutputs(recipients, assetToSend)
+	if err != nil {
+		return nil, err
+	}
+	return s.pushTransferOutputs(sender, list, unspent, assetToSend, networkFeeAmount)
+}
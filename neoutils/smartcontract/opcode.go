@@ -113,6 +113,7 @@ const (
 	HASH160       OpCode = 0xA9
 	HASH256       OpCode = 0xAA
 	CHECKSIG      OpCode = 0xAC
+	VERIFY        OpCode = 0xAD // The message, signature and public key are popped and checked like CHECKSIG.
 	CHECKMULTISIG OpCode = 0xAE
 
 	// Array
@@ -123,6 +124,7 @@ const (
 	SETITEM   OpCode = 0xC4
 	NEWARRAY  OpCode = 0xC5 //用作引用類型
 	NEWSTRUCT OpCode = 0xC6 //用作值類型
+	NEWMAP    OpCode = 0xC7
 	APPEND    OpCode = 0xC8
 	REVERSE   OpCode = 0xC9
 	REMOVE    OpCode = 0xCA
@@ -131,3 +133,114 @@ const (
 	THROW      OpCode = 0xF0
 	THROWIFNOT OpCode = 0xF1
 )
+
+// AllOpCodes returns every opcode by name so tools can build reverse lookups and validate scripts.
+// PUSHF and PUSHT are left out because they are the same as PUSH0 and PUSH1.
+// PUSHBYTES2-PUSHBYTES74 are not named, every byte between PUSHBYTES1 and PUSHBYTES75 pushes that many bytes
+func AllOpCodes() map[string]OpCode {
+	return map[string]OpCode{
+		"PUSH0":           PUSH0,
+		"PUSHBYTES1":      PUSHBYTES1,
+		"PUSHBYTES75":     PUSHBYTES75,
+		"PUSHDATA1":       PUSHDATA1,
+		"PUSHDATA2":       PUSHDATA2,
+		"PUSHDATA4":       PUSHDATA4,
+		"PUSHM1":          PUSHM1,
+		"PUSH1":           PUSH1,
+		"PUSH2":           PUSH2,
+		"PUSH3":           PUSH3,
+		"PUSH4":           PUSH4,
+		"PUSH5":           PUSH5,
+		"PUSH6":           PUSH6,
+		"PUSH7":           PUSH7,
+		"PUSH8":           PUSH8,
+		"PUSH9":           PUSH9,
+		"PUSH10":          PUSH10,
+		"PUSH11":          PUSH11,
+		"PUSH12":          PUSH12,
+		"PUSH13":          PUSH13,
+		"PUSH14":          PUSH14,
+		"PUSH15":          PUSH15,
+		"PUSH16":          PUSH16,
+		"NOP":             NOP,
+		"JMP":             JMP,
+		"JMPIF":           JMPIF,
+		"JMPIFNOT":        JMPIFNOT,
+		"CALL":            CALL,
+		"RET":             RET,
+		"APPCALL":         APPCALL,
+		"SYSCALL":         SYSCALL,
+		"TAILCALL":        TAILCALL,
+		"DUPFROMALTSTACK": DUPFROMALTSTACK,
+		"TOALTSTACK":      TOALTSTACK,
+		"FROMALTSTACK":    FROMALTSTACK,
+		"XDROP":           XDROP,
+		"XSWAP":           XSWAP,
+		"XTUCK":           XTUCK,
+		"DEPTH":           DEPTH,
+		"DROP":            DROP,
+		"DUP":             DUP,
+		"NIP":             NIP,
+		"OVER":            OVER,
+		"PICK":            PICK,
+		"ROLL":            ROLL,
+		"ROT":             ROT,
+		"SWAP":            SWAP,
+		"TUCK":            TUCK,
+		"CAT":             CAT,
+		"SUBSTR":          SUBSTR,
+		"LEFT":            LEFT,
+		"RIGHT":           RIGHT,
+		"SIZE":            SIZE,
+		"INVERT":          INVERT,
+		"AND":             AND,
+		"OR":              OR,
+		"XOR":             XOR,
+		"EQUAL":           EQUAL,
+		"INC":             INC,
+		"DEC":             DEC,
+		"SIGN":            SIGN,
+		"NEGATE":          NEGATE,
+		"ABS":             ABS,
+		"NOT":             NOT,
+		"NZ":              NZ,
+		"ADD":             ADD,
+		"SUB":             SUB,
+		"MUL":             MUL,
+		"DIV":             DIV,
+		"MOD":             MOD,
+		"SHL":             SHL,
+		"SHR":             SHR,
+		"BOOLAND":         BOOLAND,
+		"BOOLOR":          BOOLOR,
+		"NUMEQUAL":        NUMEQUAL,
+		"NUMNOTEQUAL":     NUMNOTEQUAL,
+		"LT":              LT,
+		"GT":              GT,
+		"LTE":             LTE,
+		"GTE":             GTE,
+		"MIN":             MIN,
+		"MAX":             MAX,
+		"WITHIN":          WITHIN,
+		"SHA1":            SHA1,
+		"SHA256":          SHA256,
+		"HASH160":         HASH160,
+		"HASH256":         HASH256,
+		"CHECKSIG":        CHECKSIG,
+		"VERIFY":          VERIFY,
+		"CHECKMULTISIG":   CHECKMULTISIG,
+		"ARRAYSIZE":       ARRAYSIZE,
+		"PACK":            PACK,
+		"UNPACK":          UNPACK,
+		"PICKITEM":        PICKITEM,
+		"SETITEM":         SETITEM,
+		"NEWARRAY":        NEWARRAY,
+		"NEWSTRUCT":       NEWSTRUCT,
+		"NEWMAP":          NEWMAP,
+		"APPEND":          APPEND,
+		"REVERSE":         REVERSE,
+		"REMOVE":          REMOVE,
+		"THROW":           THROW,
+		"THROWIFNOT":      THROWIFNOT,
+	}
+}
@@ -0,0 +1,39 @@
+package smartcontract_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestAllOpCodes(t *testing.T) {
+	opcodes := smartcontract.AllOpCodes()
+	known := map[string]smartcontract.OpCode{
+		"PUSH0":         0x00,
+		"PUSHDATA1":     0x4C,
+		"PUSH16":        0x60,
+		"APPCALL":       0x67,
+		"CHECKSIG":      0xAC,
+		"VERIFY":        0xAD,
+		"CHECKMULTISIG": 0xAE,
+		"PACK":          0xC1,
+		"THROWIFNOT":    0xF1,
+	}
+	for name, value := range known {
+		if opcodes[name] != value {
+			t.Fatalf("expected %v = 0x%02x got 0x%02x", name, byte(value), byte(opcodes[name]))
+		}
+	}
+
+	//every name maps to a different byte so the map can be reversed
+	names := map[smartcontract.OpCode]string{}
+	for name, value := range opcodes {
+		if other, exist := names[value]; exist {
+			t.Fatalf("%v and %v are both 0x%02x", name, other, byte(value))
+		}
+		names[value] = name
+	}
+	if names[smartcontract.SYSCALL] != "SYSCALL" {
+		t.Fatalf("expected SYSCALL got %v", names[smartcontract.SYSCALL])
+	}
+}
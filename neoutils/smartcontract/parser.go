@@ -54,7 +54,7 @@ func ReadNEOAddress(reader *bufio.Reader) (*NEOAddress, error) {
 	buf := make([]byte, length)
 	reader.Read(buf)
 
-	address := btckey.B58checkencodeNEO(0x17, buf)
+	address := btckey.B58checkencodeNEO(AddressVersion, buf)
 	neoAddress := ParseNEOAddress(address)
 	return &neoAddress, nil
 }
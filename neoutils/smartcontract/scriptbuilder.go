@@ -5,9 +5,13 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math/big"
 	"sort"
+	"strings"
 
 	"github.com/o3labs/neo-utils/neoutils/btckey"
 	"golang.org/x/crypto/ripemd160"
@@ -18,6 +22,12 @@ type NEOAddress []byte
 
 type TokenAmount uint
 
+type emptyArray struct{}
+
+//EmptyArray is an explicit empty array argument. it's pushed as PUSH0 PACK, the same as neo-cli and []interface{}{}
+//integer 0, false and nil are PUSH0 alone. the PACK after PUSH0 is what turns the count into an empty array
+var EmptyArray = emptyArray{}
+
 const (
 	Uint160Length = 20
 )
@@ -26,30 +36,125 @@ func (s ScriptHash) ToString() string {
 	return hex.EncodeToString(s)
 }
 
+//AddressVersion is the version byte of the addresses this package parses and creates.
+//0x17 is NEO 2 and it's the default. set it to NEO3AddressVersion to work with NEO 3 addresses
+var AddressVersion byte = NEO2AddressVersion
+
+const (
+	NEO2AddressVersion byte = 0x17
+	NEO3AddressVersion byte = 0x35
+)
+
 func ParseNEOAddress(address string) NEOAddress {
-	v, b, _ := btckey.B58checkdecode(address)
-	if v != 0x17 {
+	return ParseNEOAddressWithVersion(address, AddressVersion)
+}
+
+//ParseNEOAddressWithVersion returns nil when the address doesn't have the given version byte
+func ParseNEOAddressWithVersion(address string, version byte) NEOAddress {
+	n, err := parseNEOAddress(address, version)
+	if err != nil {
 		return nil
 	}
-	return NEOAddress(b)
+	return n
+}
+
+//ParseNEOAddressWithError is ParseNEOAddress that tells why the address is invalid
+//instead of returning nil for both a Base58Check decode failure and a version mismatch
+func ParseNEOAddressWithError(address string) (NEOAddress, error) {
+	return parseNEOAddress(address, AddressVersion)
+}
+
+//AddressToScriptHash returns the script hash of the address in little endian the same as NewScriptHash
+//e.g. for the argument of balanceOf
+func AddressToScriptHash(address string) (ScriptHash, error) {
+	n, err := parseNEOAddress(address, AddressVersion)
+	if err != nil {
+		return nil, err
+	}
+	return ScriptHash(n), nil
 }
+
+func parseNEOAddress(address string, version byte) (NEOAddress, error) {
+	err := validateBase58Characters(address)
+	if err != nil {
+		return nil, err
+	}
+	v, b, err := btckey.B58checkdecode(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NEO address %v: %v", address, err)
+	}
+	if v != version {
+		return nil, fmt.Errorf("invalid NEO address version 0x%02x expected 0x%02x", v, version)
+	}
+	return NEOAddress(b), nil
+}
+
 func NEOAddressFromScriptHash(scriptHashBytes []byte) NEOAddress {
-	address := btckey.B58checkencodeNEO(0x17, reverseBytes(scriptHashBytes))
+	address := btckey.B58checkencodeNEO(AddressVersion, reverseBytes(scriptHashBytes))
 	return ParseNEOAddress(address)
 }
 
+//returns the 20 bytes script hash of the address
+//NEOAddress from ParseNEOAddress is already 20 bytes but it could also be created from
+//the full decoded address [version(1)] + [script hash(20)] + [checksum(4)]
+func (n NEOAddress) scriptHashBytes() ([]byte, error) {
+	switch len(n) {
+	case Uint160Length:
+		return []byte(n), nil
+	case Uint160Length + 1:
+		if n[0] != AddressVersion {
+			return nil, fmt.Errorf("invalid NEO address version 0x%02x", n[0])
+		}
+		return []byte(n[1:]), nil
+	case Uint160Length + 5:
+		if n[0] != AddressVersion {
+			return nil, fmt.Errorf("invalid NEO address version 0x%02x", n[0])
+		}
+		hash := sha256.Sum256(n[:Uint160Length+1])
+		hash = sha256.Sum256(hash[:])
+		if bytes.Equal(hash[0:4], n[Uint160Length+1:]) == false {
+			return nil, fmt.Errorf("invalid NEO address checksum")
+		}
+		return []byte(n[1 : Uint160Length+1]), nil
+	}
+	return nil, fmt.Errorf("invalid NEO address length %v", len(n))
+}
+
 func (n NEOAddress) ToString() string {
-	return btckey.B58checkencodeNEO(0x17, n)
+	return btckey.B58checkencodeNEO(AddressVersion, n)
 }
 
 type ScriptBuilderInterface interface {
 	GenerateContractInvocationScript(scriptHash ScriptHash, operation string, args []interface{}) []byte
 	GenerateContractInvocationData(scriptHash ScriptHash, operation string, args []interface{}) []byte
+	GenerateContractInvocationScriptWithError(scriptHash ScriptHash, operation string, args []interface{}) ([]byte, error)
+	GenerateContractInvocationDataWithError(scriptHash ScriptHash, operation string, args []interface{}) ([]byte, error)
+	//script + gas of a version 1 invocation transaction
+	GenerateInvocationTransactionData(script []byte, gas Fixed8) ([]byte, error)
 	GenerateTransactionAttributes(attributes map[TransactionAttribute][]byte) ([]byte, error)
 
 	//this is to send the UTXO of asset that will be used in TransactionOutput
 	GenerateTransactionInput(unspent Unspent, assetToSend NativeAsset, amountToSend float64, networkFeeAmount NetworkFeeAmount) ([]byte, error)
 	GenerateTransactionOutput(sender NEOAddress, receiver NEOAddress, unspent Unspent, assetToSend NativeAsset, amountToSend float64, networkFeeAmount NetworkFeeAmount) ([]byte, error)
+	//one output for each recipient sorted by script hash
+	GenerateMultiRecipientTransactionInput(recipients map[string]float64, unspent Unspent, assetToSend NativeAsset, networkFeeAmount NetworkFeeAmount) ([]byte, error)
+	GenerateMultiRecipientTransactionOutput(sender NEOAddress, recipients map[string]float64, unspent Unspent, assetToSend NativeAsset, networkFeeAmount NetworkFeeAmount) ([]byte, error)
+	//checks the balance of every asset a transaction spends at once
+	ValidateBalances(unspent Unspent, required map[NativeAsset]float64) error
+
+	//coin control. spends exactly the given UTXOs of assetToSend
+	GenerateTransactionInputFromUTXOs(utxos []UTXO, assetToSend NativeAsset, amountToSend float64, networkFeeAmount NetworkFeeAmount) ([]byte, error)
+	GenerateTransactionOutputFromUTXOs(sender NEOAddress, receiver NEOAddress, utxos []UTXO, assetToSend NativeAsset, amountToSend float64, networkFeeAmount NetworkFeeAmount) ([]byte, error)
+	//takes UTXOs from the channel until they cover amount
+	SelectUTXOsFromChannel(utxos <-chan UTXO, amount float64) ([]UTXO, Fixed8, error)
+
+	//sponsored transaction. the sender spends the asset and the fee payer spends GAS for the network fee
+	GenerateTransactionInputWithFeePayer(unspent Unspent, assetToSend NativeAsset, amountToSend float64, feePayerUnspent Unspent, networkFeeAmount NetworkFeeAmount) ([]byte, error)
+	GenerateTransactionOutputWithFeePayer(sender NEOAddress, receiver NEOAddress, unspent Unspent, assetToSend NativeAsset, amountToSend float64, feePayer NEOAddress, feePayerUnspent Unspent, networkFeeAmount NetworkFeeAmount) ([]byte, error)
+
+	//claim transaction. claims is the list of spent NEO outputs and amount is the claimable GAS
+	GenerateClaimTransactionData(claims []UTXO) ([]byte, error)
+	GenerateClaimTransactionOutput(receiver NEOAddress, amount float64) ([]byte, error)
 
 	GenerateVerificationScripts(signatures []interface{}) []byte
 
@@ -63,20 +168,60 @@ type ScriptBuilderInterface interface {
 	//public method to wrap pushData
 	Push(data interface{}) error
 	PushOpCode(opcode OpCode)
+	//appends bytes that are already serialized e.g. a script built by another ScriptBuilder
+	EmitRaw(b []byte)
+	EmitFixedWidthInt(value *big.Int, width int) error
+	//pushes the parameters in reverse order then PACK so the contract gets them as one array
+	EmitArray(params []ContractParameter) error
 
 	ToScriptHash() []byte //UInt160
 
 	pushInt(value int) error
 	pushData(data interface{}) error
-	pushLength(count int)
+	pushLength(count int) error
 }
 
 func NewScriptBuilder() ScriptBuilderInterface {
 	return &ScriptBuilder{RawBytes: []byte{}}
 }
 
+//NewScriptBuilderWithWriter returns a script builder that writes to w every time data is pushed
+//instead of keeping the whole script in memory.
+//only Push, PushOpCode, EmitRaw, EmitFixedWidthInt and EmitArray stream. the Generate methods need the whole script in memory
+func NewScriptBuilderWithWriter(w io.Writer) ScriptBuilderInterface {
+	return &ScriptBuilder{RawBytes: []byte{}, Writer: w}
+}
+
 type ScriptBuilder struct {
 	RawBytes []byte
+	//when true, GenerateTransactionOutput rejects any output over the maximum supply of the asset
+	CheckMaximumAmount bool
+	//maximum size in bytes of a single pushed item. 0 means no limit
+	//the VM rejects a script with a stack item larger than what it allows so it's better to fail early here
+	MaxItemSize int
+	//UTXOs with fewer confirmations are not selected as inputs. 0 means unconfirmed UTXOs can be spent
+	//so a transaction is not built on top of a parent that could still be dropped
+	MinimumConfirmations int
+	//maximum number of UTXOs selected for each asset. 0 means no limit
+	//a transaction with too many inputs is over the size a node accepts.
+	//GenerateTransactionOutput selects the UTXOs again for the change so use the same value for the inputs and the outputs
+	MaxInputs int
+	//GAS change under DustThreshold doesn't get an output and goes to the network fee instead. 0 means any change gets an output
+	//only GAS can be left out of the outputs. a node rejects a transaction that destroys any other asset
+	DustThreshold float64
+	//when set, pushed bytes are written to Writer and RawBytes only holds what hasn't been written yet
+	Writer   io.Writer
+	writeErr error
+}
+
+//writes everything pushed so far to Writer
+func (s *ScriptBuilder) flush() error {
+	if s.Writer == nil || s.writeErr != nil {
+		return s.writeErr
+	}
+	_, s.writeErr = s.Writer.Write(s.RawBytes)
+	s.RawBytes = s.RawBytes[:0]
+	return s.writeErr
 }
 
 func (s *ScriptBuilder) ToScriptHash() []byte {
@@ -104,7 +249,14 @@ func (s ScriptBuilder) FullHexString() string {
 
 func (s *ScriptBuilder) PushOpCode(opcode OpCode) {
 	s.RawBytes = append(s.RawBytes, byte(opcode))
+	s.flush()
+}
+//EmitRaw appends b as is without a length prefix
+func (s *ScriptBuilder) EmitRaw(b []byte) {
+	s.RawBytes = append(s.RawBytes, b...)
+	s.flush()
 }
+
 func (s *ScriptBuilder) pushInt8bytes(value int) error {
 	num := make([]byte, 8)
 	binary.LittleEndian.PutUint64(num, uint64(value))
@@ -112,6 +264,10 @@ func (s *ScriptBuilder) pushInt8bytes(value int) error {
 }
 
 func (s *ScriptBuilder) pushInt(value int) error {
+	return s.pushInt64(int64(value))
+}
+
+func (s *ScriptBuilder) pushInt64(value int64) error {
 	switch {
 	case value == -1:
 		s.PushOpCode(PUSHM1)
@@ -119,29 +275,56 @@ func (s *ScriptBuilder) pushInt(value int) error {
 	case value == 0:
 		s.PushOpCode(PUSH0)
 		return nil
-	case value >= 1 && value < 16:
+	case value >= 1 && value <= 16:
 		rawValue := byte(PUSH1) + byte(value) - 1
 		s.RawBytes = append(s.RawBytes, rawValue)
 		return nil
-	case value >= 16:
-		num := make([]byte, 8)
-		binary.LittleEndian.PutUint64(num, uint64(value))
-		//we push as []byte so then it prefixes with length
-		s.pushData(bytes.Trim(num, "\x00"))
-		return nil
 	}
-	return nil
+	//anything else can't use the PUSH opcodes so it's pushed as []byte then it prefixes with length
+	return s.pushData(intToBytes(value))
 }
 
-func (s *ScriptBuilder) pushLength(count int) {
-	if count == 0 {
-		s.RawBytes = append(s.RawBytes, 0x00)
-		return
+//EmitFixedWidthInt pushes an integer as a little endian byte array of exactly width bytes
+//for contracts that expect a fixed width integer instead of the minimal encoding.
+//negative values are padded with 0xff (two's complement)
+func (s *ScriptBuilder) EmitFixedWidthInt(value *big.Int, width int) error {
+	if width <= 0 {
+		return fmt.Errorf("invalid width %v", width)
+	}
+	//signed range of width bytes
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(width*8-1))
+	if value.Cmp(limit) >= 0 || value.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return fmt.Errorf("%v does not fit in %v bytes", value, width)
+	}
+	v := new(big.Int).Set(value)
+	if v.Sign() < 0 {
+		v.Add(v, new(big.Int).Lsh(big.NewInt(1), uint(width*8)))
 	}
-	countBytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(countBytes, uint64(count))
-	trimmedCountByte := bytes.Trim(countBytes, "\x00")
-	s.RawBytes = append(s.RawBytes, trimmedCountByte...)
+	b := make([]byte, width)
+	bigEndian := v.Bytes()
+	copy(b[width-len(bigEndian):], bigEndian)
+	return s.Push(reverseBytes(b))
+}
+
+//maximum count NEO reads for a var int prefixed array. same as ReadSerializableArray in neo-cli
+const maxVarIntCount = 0x1000000
+
+//pushes count as a var int. a negative count or one over what NEO reads is an error instead of a wrong prefix
+func (s *ScriptBuilder) pushLength(count int) error {
+	if count < 0 {
+		return fmt.Errorf("invalid length %v. length can't be negative", count)
+	}
+	if uint64(count) > maxVarIntCount {
+		return fmt.Errorf("invalid length %v. NEO doesn't read more than %v", count, maxVarIntCount)
+	}
+	s.RawBytes = append(s.RawBytes, varIntBytes(uint64(count))...)
+	return nil
+}
+
+//var int length + data
+func (s *ScriptBuilder) pushVarBytes(b []byte) {
+	s.RawBytes = append(s.RawBytes, varIntBytes(uint64(len(b)))...)
+	s.RawBytes = append(s.RawBytes, b...)
 }
 
 func (s *ScriptBuilder) pushHexString(hexString string) error {
@@ -149,57 +332,75 @@ func (s *ScriptBuilder) pushHexString(hexString string) error {
 	if err != nil {
 		return err
 	}
-	count := len(b)
-	countBytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(countBytes, uint64(count))
-	trimmedCountByte := bytes.TrimRight(countBytes, "\x00")
+	return s.pushBytes(b)
+}
 
-	if count < int(PUSHBYTES75) {
-		s.RawBytes = append(s.RawBytes, trimmedCountByte...)
-		s.RawBytes = append(s.RawBytes, b...)
-	} else if count < 0x100 {
+//length + data
+func (s *ScriptBuilder) pushBytes(b []byte) error {
+	count := len(b)
+	if s.MaxItemSize > 0 && count > s.MaxItemSize {
+		return fmt.Errorf("item size %v bytes exceeds the maximum item size of %v bytes", count, s.MaxItemSize)
+	}
+	//PUSHBYTES1-75 is the length itself. PUSHDATA1, 2 and 4 are followed by the length in 1, 2 and 4 bytes
+	//this is the same for an element nested in an array since pushData is called for every element
+	switch {
+	case count == 0:
+		//empty byte array
+		s.PushOpCode(PUSH0)
+	case count <= int(PUSHBYTES75):
+		s.RawBytes = append(s.RawBytes, byte(count))
+	case count < 0x100:
 		s.PushOpCode(PUSHDATA1)
-		s.RawBytes = append(s.RawBytes, trimmedCountByte...)
-		s.RawBytes = append(s.RawBytes, b...)
-	} else if count < 0x10000 {
+		s.RawBytes = append(s.RawBytes, byte(count))
+	case count < 0x10000:
 		s.PushOpCode(PUSHDATA2)
-		s.RawBytes = append(s.RawBytes, trimmedCountByte...)
-		s.RawBytes = append(s.RawBytes, b...)
-	} else {
+		s.RawBytes = append(s.RawBytes, uint16ToFixBytes(uint16(count))...)
+	default:
+		countBytes := make([]byte, 4)
+		binary.LittleEndian.PutUint32(countBytes, uint32(count))
 		s.PushOpCode(PUSHDATA4)
-		s.RawBytes = append(s.RawBytes, trimmedCountByte...)
-		s.RawBytes = append(s.RawBytes, b...)
+		s.RawBytes = append(s.RawBytes, countBytes...)
 	}
+	s.RawBytes = append(s.RawBytes, b...)
 	return nil
 }
 
 func (s *ScriptBuilder) Push(data interface{}) error {
-	return s.pushData(data)
+	err := s.pushData(data)
+	if err != nil {
+		return err
+	}
+	return s.flush()
 }
 
 func (s *ScriptBuilder) pushData(data interface{}) error {
 	switch e := data.(type) {
+	case ContractParameter:
+		return s.pushContractParameter(e)
 	case TransactionValidationScript:
-		s.pushData(e.StackScript)
-		if e.RedeemScript == nil {
-			s.RawBytes = append(s.RawBytes, 0x00)
-		} else {
-			s.pushData(e.RedeemScript)
-		}
+		//both scripts in a witness are prefixed with a var int length not a PUSHDATA opcode
+		//a nil RedeemScript is pushed as 0x00
+		s.pushVarBytes(e.StackScript)
+		s.pushVarBytes(e.RedeemScript)
 		return nil
 	case TransactionSignature:
 		signatureLength := len(e.SignedData)
 		b := []byte{}
 		b = append(b, uintToBytes(uint(signatureLength))...)
 		b = append(b, e.SignedData...)
-		s.pushLength(len(b)) //this should be 0x41
+		err := s.pushLength(len(b)) //this should be 0x41
+		if err != nil {
+			return err
+		}
 		s.RawBytes = append(s.RawBytes, b...)
 		s.RawBytes = append(s.RawBytes, 0x23) //0x23 = 35 this is the length of the next [publickey.length(2)]+[publickey(33)]]
 		//this part is for verification script
 		//push public key in there and call CHECKSIG or CHECKMULTISIG
-		s.pushData(e.PublicKey)
-		return nil
+		return s.pushData(e.PublicKey)
 	case TransactionOutput:
+		if e.Value < 0 {
+			return fmt.Errorf("output amount %v of %v is negative", e.Value, e.Asset)
+		}
 		s.RawBytes = append(s.RawBytes, e.Asset.ToLittleEndianBytes()...) //32 bytes
 		amountToSendBytes := make([]byte, 8)
 		binary.LittleEndian.PutUint64(amountToSendBytes, uint64(e.Value))
@@ -213,12 +414,15 @@ func (s *ScriptBuilder) pushData(data interface{}) error {
 		if has0xPrefix(e.TXID) == true {
 			trimmed0x = e.TXID[2:]
 		}
-		//reverse txID to little endian
 		b, err := hex.DecodeString(trimmed0x)
 		if err != nil {
 			return err
 		}
-		littleEndianTXID := reverseBytes(b)
+		//reverse txID to little endian unless it already is
+		littleEndianTXID := b
+		if e.TXIDByteOrder != binary.LittleEndian {
+			littleEndianTXID = reverseBytes(b)
+		}
 		index := e.Index
 		s.RawBytes = append(s.RawBytes, littleEndianTXID...)
 		intBytes := uint16ToFixBytes(uint16(index))
@@ -234,16 +438,27 @@ func (s *ScriptBuilder) pushData(data interface{}) error {
 		s.RawBytes = append(s.RawBytes, byte(e))
 		return nil
 	case NEOAddress:
-		//when pushing neo address as an arg. we need length so we need to push a hex string
-		return s.pushHexString(fmt.Sprintf("%x", e))
+		//the address payload is already the 20 bytes script hash in the same order contracts read it
+		//e.g. Runtime.CheckWitness and storage keys. so it's pushed as is without reversing.
+		//when pushing neo address as an arg. we need length so we push it as a byte array
+		b, err := e.scriptHashBytes()
+		if err != nil {
+			return err
+		}
+		return s.pushData(b)
 	case ScriptHash:
 		s.RawBytes = append(s.RawBytes, e...)
 		return nil
+	case Hash160:
+		//as an argument it's a byte array in little endian. PUSHBYTES20 + 20 bytes
+		return s.pushData(e.ToLittleEndianBytes())
+	case Hash256:
+		//PUSHBYTES32 + 32 bytes in little endian
+		return s.pushData(e.ToLittleEndianBytes())
 	case string:
 		return s.pushHexString(e)
 	case []byte:
-		// length + data
-		return s.pushHexString(hex.EncodeToString(e))
+		return s.pushBytes(e)
 	case bool:
 		if e == true {
 			s.PushOpCode(PUSH1)
@@ -252,15 +467,29 @@ func (s *ScriptBuilder) pushData(data interface{}) error {
 		}
 		return nil
 	case []interface{}:
+		//an array is its items in reverse order then the count then PACK.
+		//an empty array is PUSH0 PACK so it's never mistaken for the integer 0 which is PUSH0 alone, even when it's nested
 		count := len(e)
 		//reverse the array first
 		for i := len(e) - 1; i >= 0; i-- {
-			s.pushData(e[i])
+			err := s.pushData(e[i])
+			if err != nil {
+				return err
+			}
 		}
 		s.pushInt(count)
 		s.PushOpCode(PACK)
 		return nil
+	case nil:
+		//an optional argument that isn't set. PUSH0 is an empty byte array so the other arguments keep their position
+		s.PushOpCode(PUSH0)
+		return nil
+	case emptyArray:
+		s.pushInt(0)
+		s.PushOpCode(PACK)
+		return nil
 	case int:
+		//0 is PUSH0 without PACK. the contract reads it as 0 or an empty byte array, not as an array
 		s.pushInt(e)
 		return nil
 	case int64:
@@ -297,27 +526,137 @@ func (s ScriptHash) ToBigEndian() []byte {
 	return reverseBytes([]byte(s))
 }
 
+//ToBigEndianString returns the script hash in hex the way neo-cli shows it. the script hash itself is left as it is
+func (s ScriptHash) ToBigEndianString() string {
+	b := make([]byte, len(s))
+	copy(b, s)
+	return hex.EncodeToString(reverseBytes(b))
+}
+
+//ToExplorerString returns the script hash the way block explorers show it e.g. 0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9
+func (s ScriptHash) ToExplorerString() string {
+	return "0x" + s.ToBigEndianString()
+}
+
+//operation is in string we need to convert it to hex first.
+//an empty operation is for contracts invoked directly without an operation name.
+//it's pushed as PUSH0 (0x00) which the VM treats as an empty byte array, same as neo-cli does for ""
+func (s *ScriptBuilder) pushOperation(operation string) error {
+	if len(operation) == 0 {
+		s.PushOpCode(PUSH0)
+		return nil
+	}
+	return s.pushData([]byte(operation))
+}
+
+//args + operation + APPCALL + script hash. the whole script stays in RawBytes until it's complete
+//so nothing is written to Writer and RawBytes is back to where it was when an argument can't be pushed
+func (s *ScriptBuilder) pushContractInvocation(scriptHash ScriptHash, operation string, args []interface{}) error {
+	writer := s.Writer
+	s.Writer = nil
+	defer func() { s.Writer = writer }()
+
+	start := len(s.RawBytes)
+	err := func() error {
+		if args != nil {
+			err := s.pushData(args)
+			if err != nil {
+				return err
+			}
+		}
+		err := s.pushOperation(operation)
+		if err != nil {
+			return err
+		}
+		s.PushOpCode(APPCALL)         //use APPCALL only
+		return s.pushData(scriptHash) //script hash of the smart contract that we want to invoke
+	}()
+	if err != nil {
+		s.RawBytes = s.RawBytes[:start]
+		return err
+	}
+	return nil
+}
+
 // This is in a format of main(string operation, []object args) in c#
+//it returns nil when an argument can't be pushed e.g. it's over MaxItemSize. GenerateContractInvocationDataWithError tells why
 func (s *ScriptBuilder) GenerateContractInvocationData(scriptHash ScriptHash, operation string, args []interface{}) []byte {
-	if args != nil {
-		s.pushData(args)
+	b, err := s.GenerateContractInvocationDataWithError(scriptHash, operation, args)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+//GenerateContractInvocationDataWithError is GenerateContractInvocationData that returns the error of an argument
+//instead of a script without it
+func (s *ScriptBuilder) GenerateContractInvocationDataWithError(scriptHash ScriptHash, operation string, args []interface{}) ([]byte, error) {
+	err := s.pushContractInvocation(scriptHash, operation, args)
+	if err != nil {
+		return nil, err
 	}
-	s.pushData([]byte(operation))                                     //operation is in string we need to convert it to hex first
-	s.PushOpCode(APPCALL)                                             //use APPCALL only
-	s.pushData(scriptHash)                                            //script hash of the smart contract that we want to invoke
 	s.RawBytes = append([]byte{byte(len(s.RawBytes))}, s.RawBytes...) //the length of the entire raw bytes
-	return s.ToBytes()
+	return s.generatedBytes()
+}
+
+//GenerateInvocationTransactionData returns the exclusive data of a version 1 invocation transaction
+//[var int length] + [script] + [gas fixed8 8 bytes]. gas is the system fee paid to run the script and neo only accepts whole GAS.
+//use it with NewInvocationTransactionWithGas. a version 0 transaction has no gas field
+func (s *ScriptBuilder) GenerateInvocationTransactionData(script []byte, gas Fixed8) ([]byte, error) {
+	if len(script) == 0 {
+		return nil, fmt.Errorf("invocation script is empty")
+	}
+	if len(script) > maxInvocationScriptSize {
+		return nil, fmt.Errorf("invocation script of %v bytes exceeds %v bytes", len(script), maxInvocationScriptSize)
+	}
+	if gas < 0 {
+		return nil, fmt.Errorf("gas %v is negative", gas)
+	}
+	if gas%NewFixed8(1) != 0 {
+		return nil, fmt.Errorf("gas %v must be a whole number of GAS", gas)
+	}
+	err := s.pushLength(len(script))
+	if err != nil {
+		return nil, err
+	}
+	s.RawBytes = append(s.RawBytes, script...)
+	gasBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(gasBytes, uint64(gas))
+	s.RawBytes = append(s.RawBytes, gasBytes...)
+	return s.ToBytes(), nil
 }
 
 // when generate the invokescript we don't need the length of the whole script
+//it returns nil when an argument can't be pushed e.g. it's over MaxItemSize. GenerateContractInvocationScriptWithError tells why
 func (s *ScriptBuilder) GenerateContractInvocationScript(scriptHash ScriptHash, operation string, args []interface{}) []byte {
-	if args != nil {
-		s.pushData(args)
+	b, err := s.GenerateContractInvocationScriptWithError(scriptHash, operation, args)
+	if err != nil {
+		return nil
 	}
-	s.pushData([]byte(operation)) //operation is in string we need to convert it to hex first
-	s.PushOpCode(APPCALL)         //use APPCALL only
-	s.pushData(scriptHash)        //script hash of the smart contract that we want to invoke
-	return s.ToBytes()
+	return b
+}
+
+//GenerateContractInvocationScriptWithError is GenerateContractInvocationScript that returns the error of an argument
+//instead of a script without it
+func (s *ScriptBuilder) GenerateContractInvocationScriptWithError(scriptHash ScriptHash, operation string, args []interface{}) ([]byte, error) {
+	err := s.pushContractInvocation(scriptHash, operation, args)
+	if err != nil {
+		return nil, err
+	}
+	return s.generatedBytes()
+}
+
+//the generated script. with a Writer it's written out once it's complete and the returned bytes are a copy
+func (s *ScriptBuilder) generatedBytes() ([]byte, error) {
+	if s.Writer == nil {
+		return s.ToBytes(), nil
+	}
+	b := append([]byte{}, s.RawBytes...)
+	err := s.flush()
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
 }
 
 func (s *ScriptBuilder) EmptyTransactionAttributes() []byte {
@@ -328,17 +667,19 @@ func (s *ScriptBuilder) EmptyTransactionAttributes() []byte {
 func (s *ScriptBuilder) GenerateTransactionAttributes(attributes map[TransactionAttribute][]byte) ([]byte, error) {
 
 	count := len(attributes)
-	s.pushLength(count) //number of transaction attributes
+	err := s.pushLength(count) //number of transaction attributes
+	if err != nil {
+		return nil, err
+	}
 	// N x transaction attribute
-	//transaction attribute =  TransactionAttribute + data.length + data
-	for k, v := range attributes {
-		s.pushData(k) //transaction attribute usage
-		if k == Script {
-			//if it's a Script field, we just need to put it as is in a little endian bytes
-			s.pushData(ScriptHash(v))
-		} else {
-			s.pushData(v) //push byte data in already includes the length of the data
+	//transaction attribute =  TransactionAttribute + data
+	for _, k := range sortedAttributeUsages(attributes) {
+		data, err := attributeDataBytes(k, attributes[k])
+		if err != nil {
+			return nil, err
 		}
+		s.pushData(k) //transaction attribute usage
+		s.RawBytes = append(s.RawBytes, data...)
 	}
 
 	return s.ToBytes(), nil
@@ -352,66 +693,15 @@ func (s *ScriptBuilder) GenerateTransactionInput(unspent Unspent, assetToSend Na
 		s.pushLength(0)
 		return s.ToBytes(), nil
 	}
-	sendingAsset := unspent.Assets[assetToSend]
-	if sendingAsset == nil {
-		return nil, fmt.Errorf("Asset %v not found in UTXO", assetToSend)
-	}
-	//network fee
-	feeAmount := networkFeeAmount
-
-	//if assetToSend is NEO and fee amount is more than zero
-	needAnotherAssetForFee := false
-	if assetToSend == NEO && feeAmount > 0 {
-		//we need another input because fee is in GAS
-		needAnotherAssetForFee = true
-	}
-
-	if amountToSend > sendingAsset.TotalAmount() {
-		return nil, fmt.Errorf("input Don't have enough balance. Sending %v but only have %v", amountToSend, sendingAsset.TotalAmount())
-	}
-
-	//sort min first
-	sendingAsset.SortMinFirst()
-
-	utxoSumAmount := float64(0)
-	index := 0
-	count := 0
-	inputs := []UTXO{}
-	//loop until we get enough sum amount
-	for utxoSumAmount < amountToSend {
-		addingUTXO := sendingAsset.UTXOs[index]
-		log.Printf("%+v", addingUTXO)
-		inputs = append(inputs, addingUTXO)
-		utxoSumAmount += addingUTXO.Value
-		index += 1
-		count += 1
-	}
 
-	//fee input part
-	if needAnotherAssetForFee == true {
-		gasBalanceForFee := unspent.Assets[GAS]
-		gasBalanceForFee.SortMinFirst()
-		if float64(feeAmount) > gasBalanceForFee.TotalAmount() {
-			return nil, fmt.Errorf("you don't have enough balance for network fee.")
-		}
-		utxoSumFeeAmount := float64(0)
-		feeIndex := 0
-		for utxoSumFeeAmount < float64(feeAmount) {
-			addingUTXO := gasBalanceForFee.UTXOs[feeIndex]
-			inputs = append(inputs, addingUTXO)
-			utxoSumFeeAmount += addingUTXO.Value
-			feeIndex += 1
-			count += 1
-		}
-		//end fee input part
+	inputs, _, err := s.spendUTXOs(nil, unspent, assetToSend, NewFixed8(amountToSend), networkFeeAmount)
+	if err != nil {
+		return nil, err
 	}
-
-	s.pushLength(count)
-	for _, v := range inputs {
-		//push utxo data
-		s.pushData(v)
+	err = s.pushInputs(inputs)
+	if err != nil {
+		return nil, err
 	}
-
 	return s.ToBytes(), nil
 }
 
@@ -425,120 +715,343 @@ func (s *ScriptBuilder) GenerateTransactionOutput(sender NEOAddress, receiver NE
 		return s.ToBytes(), nil
 	}
 
+	if unspent.Assets[assetToSend] != nil && s.CheckMaximumAmount == true {
+		err := assetToSend.ValidateAmount(amountToSend)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//first output is the amount to send to the receiver
+	list := []TransactionOutput{
+		{
+			Asset:   assetToSend,
+			Value:   NewFixed8(amountToSend),
+			Address: receiver,
+		},
+	}
+	return s.pushTransferOutputs(sender, list, unspent, assetToSend, networkFeeAmount)
+}
+
+//pushTransferOutputs pushes the outputs to the receivers followed by the change back to sender
+//of the same inputs GenerateTransactionInput selects for the sum of the receiver outputs
+func (s *ScriptBuilder) pushTransferOutputs(sender NEOAddress, list []TransactionOutput, unspent Unspent, assetToSend NativeAsset, networkFeeAmount NetworkFeeAmount) ([]byte, error) {
+	amount := Fixed8(0)
+	for _, v := range list {
+		amount = amount.Add(v.Value)
+	}
+	_, change, err := s.spendUTXOs(sender, unspent, assetToSend, amount, networkFeeAmount)
+	if err != nil {
+		return nil, err
+	}
+	list = append(list, change...)
+
+	if s.CheckMaximumAmount == true {
+		for _, v := range list {
+			err := v.Asset.ValidateAmount(v.Value.Float64())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	//number of outputs
+	err = s.pushOutputs(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ToBytes(), nil
+}
+
+//spendUTXOs selects the UTXOs that pay amount of assetToSend and the network fee and returns them with the change back to sender.
+//the inputs and the outputs of a transfer both come from here so they always spend the same UTXOs
+func (s *ScriptBuilder) spendUTXOs(sender NEOAddress, unspent Unspent, assetToSend NativeAsset, amount Fixed8, networkFeeAmount NetworkFeeAmount) ([]UTXO, []TransactionOutput, error) {
 	sendingAsset := unspent.Assets[assetToSend]
 	if sendingAsset == nil {
-		return nil, fmt.Errorf("Asset %v not found in UTXO", assetToSend)
+		return nil, nil, fmt.Errorf("Asset %v not found in UTXO", assetToSend)
 	}
-
 	//network fee
-	feeAmount := networkFeeAmount
+	feeAmount := NewFixed8(float64(networkFeeAmount))
 
 	//if assetToSend is NEO and fee amount is more than zero
-	needAnotherAssetForFee := false
-	if assetToSend == NEO && feeAmount > 0 {
-		needAnotherAssetForFee = true
-	}
+	//we need another input because fee is in GAS
+	needAnotherAssetForFee := assetToSend == NEO && feeAmount > 0
 
-	if amountToSend > sendingAsset.TotalAmount() {
-		return nil, fmt.Errorf("you don't have enough balance. Sending %v but only have %v", amountToSend, sendingAsset.TotalAmount())
+	//when the fee is paid in the same asset the inputs have to cover both
+	requiredAmount := amount
+	if needAnotherAssetForFee == false && feeAmount > 0 {
+		requiredAmount = amount.Add(feeAmount)
 	}
-	//sort min first
-	sendingAsset.SortMinFirst()
 
-	utxoSumAmount := float64(0)
-	index := 0
-	count := 0
-	inputs := []UTXO{}
-	for utxoSumAmount < amountToSend {
-		addingUTXO := sendingAsset.UTXOs[index]
-		inputs = append(inputs, addingUTXO)
-		utxoSumAmount += addingUTXO.Value
-		index += 1
-		count += 1
+	//loop until we get enough sum amount
+	inputs, utxoSumAmount, err := s.selectUTXOs(sendingAsset, requiredAmount.Float64())
+	if err != nil {
+		return nil, nil, err
 	}
 
-	//if the total amount of inputs is over amountToSend
+	//if the total amount of inputs is over the amount and the fee
 	//we need to send the rest back to the sending address
-	totalAmountInInputs := utxoSumAmount
-	needTwoOutputTransaction := totalAmountInInputs != amountToSend
-	list := []TransactionOutput{}
+	change := []TransactionOutput{}
+	returningAmount := utxoSumAmount.Sub(requiredAmount)
+	if returningAmount != 0 && s.isDust(assetToSend, returningAmount) == false {
+		change = append(change, TransactionOutput{Asset: assetToSend, Value: returningAmount, Address: sender})
+	}
 
-	if needTwoOutputTransaction {
-		//first output is the amount to send to the receiver
-		sendingOutput := TransactionOutput{
-			Asset:   assetToSend,
-			Value:   int64(RoundFixed8(amountToSend) * float64(100000000)),
-			Address: receiver,
+	//fee input part
+	if needAnotherAssetForFee == true {
+		feeInputs, runningFeeAmount, err := s.selectUTXOs(unspent.Assets[GAS], feeAmount.Float64())
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w. you don't have enough balance for network fee.", ErrInsufficientBalance)
 		}
-		list = append(list, sendingOutput)
+		inputs = append(inputs, feeInputs...)
 
-		//second output is the returning amount you will be sending back to yourself.
-		returningAmount := totalAmountInInputs - amountToSend
+		// To allow user to set network fee is to make send GAS back to yourself
+		// minus the amount of gas that you want it to be network fee
+		// for example
+		// GAS balance = 10
+		// sending back amount = 9
+		// this will make network fee = 1
+		returningFee := runningFeeAmount.Sub(feeAmount)
+		if returningFee != 0 && s.isDust(GAS, returningFee) == false {
+			change = append(change, TransactionOutput{Asset: GAS, Value: returningFee, Address: sender})
+		}
+	}
+	return inputs, change, nil
+}
+
+//change that is worth less than the fee to spend it later. it's left out of the outputs and goes to the network fee
+func (s *ScriptBuilder) isDust(asset NativeAsset, change Fixed8) bool {
+	return asset == GAS && s.DustThreshold > 0 && change > 0 && change < NewFixed8(s.DustThreshold)
+}
 
-		//so if we don't need another asset input and fee is more than 0
-		//we then make returningAmount = returningAmount - fee
-		if needAnotherAssetForFee == false && float64(feeAmount) > 0 {
-			returningAmount -= float64(feeAmount)
+//pushOutputs pushes the number of outputs then the outputs.
+//nothing is pushed when one of the amounts is negative so a bad change amount can't end up in a half written transaction
+func (s *ScriptBuilder) pushOutputs(list []TransactionOutput) error {
+	for _, v := range list {
+		if v.Value < 0 {
+			return fmt.Errorf("output amount %v of %v is negative", v.Value, v.Asset)
 		}
-		//return the left over to sender
-		returningOutput := TransactionOutput{
-			Asset:   assetToSend,
-			Value:   int64(RoundFixed8(returningAmount) * float64(100000000)),
-			Address: sender,
+	}
+	err := s.pushLength(len(list))
+	if err != nil {
+		return err
+	}
+	for _, v := range list {
+		err := s.pushData(v)
+		if err != nil {
+			return err
 		}
-		list = append(list, returningOutput)
-	} else {
+	}
+	return nil
+}
 
-		out := TransactionOutput{
-			Asset:   assetToSend,
-			Value:   int64(RoundFixed8(amountToSend) * float64(100000000)),
-			Address: receiver,
+//[var int count] + N x ([txid in little endian] + [index uint16]) of inputs or claims.
+//the same UTXO referenced twice makes the transaction invalid so nothing is pushed then
+func (s *ScriptBuilder) pushInputs(list []UTXO) error {
+	start := len(s.RawBytes)
+	err := s.pushLength(len(list))
+	if err != nil {
+		return err
+	}
+	for _, v := range list {
+		err := s.pushData(v)
+		if err != nil {
+			s.RawBytes = s.RawBytes[:start]
+			return err
 		}
-		list = append(list, out)
+	}
+	items, err := readFixedLengthSection(s.RawBytes[start:], transactionInputLength)
+	if err == nil {
+		err = checkDuplicateReferences(items)
+	}
+	if err != nil {
+		s.RawBytes = s.RawBytes[:start]
+		return err
+	}
+	return nil
+}
 
+//UTXOs that can be spent. UTXOs with fewer confirmations than MinimumConfirmations are skipped
+func (s *ScriptBuilder) spendableUTXOs(balance *Balance) []UTXO {
+	if s.MinimumConfirmations <= 0 {
+		return balance.UTXOs
 	}
+	spendable := []UTXO{}
+	for _, utxo := range balance.UTXOs {
+		if utxo.Confirmations < s.MinimumConfirmations {
+			continue
+		}
+		spendable = append(spendable, utxo)
+	}
+	return spendable
+}
 
-	//if set network fee is more than 0
-	//add more output for fee
-	if needAnotherAssetForFee == true {
+//ErrInsufficientBalance is returned when the spendable UTXOs don't cover the amount
+var ErrInsufficientBalance = errors.New("you don't have enough balance")
 
-		gasBalanceForFee := unspent.Assets[GAS]
-		gasBalanceForFee.SortMinFirst()
-		if float64(feeAmount) > gasBalanceForFee.TotalAmount() {
-			return nil, fmt.Errorf("you don't have enough balance for network fee.")
+//ValidateBalances checks the spendable UTXOs of every asset cover the amount required of it
+//so a transaction spending several assets fails before any bytes are built.
+//the error lists every asset that is short and wraps ErrInsufficientBalance. the network fee is part of the GAS amount
+func (s *ScriptBuilder) ValidateBalances(unspent Unspent, required map[NativeAsset]float64) error {
+	assets := []NativeAsset{}
+	for asset := range required {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i] < assets[j] })
+
+	shortfalls := []string{}
+	for _, asset := range assets {
+		amount := NewFixed8(required[asset])
+		total := Fixed8(0)
+		if balance := unspent.Assets[asset]; balance != nil {
+			for _, utxo := range s.spendableUTXOs(balance) {
+				total = total.Add(NewFixed8(utxo.Value))
+			}
 		}
-		runningFeeAmount := float64(0)
-		feeIndex := 0
-		for runningFeeAmount < float64(feeAmount) {
-			addingUTXO := gasBalanceForFee.UTXOs[feeIndex]
-			inputs = append(inputs, addingUTXO)
-			runningFeeAmount += addingUTXO.Value
-			feeIndex += 1
-			count += 1
+		if amount > total {
+			shortfalls = append(shortfalls, fmt.Sprintf("%v needs %v but only have %v", asset, amount, total))
 		}
+	}
+	if len(shortfalls) > 0 {
+		return fmt.Errorf("%w. %v", ErrInsufficientBalance, strings.Join(shortfalls, ", "))
+	}
+	return nil
+}
 
-		// To allow user to set network fee is to make send GAS back to yourself
-		// minus the amount of gas that you want it to be network fee
-		// for example
-		// GAS balance = 10
-		// sending back amount = 9
-		// this will make network fee = 1
+//picks UTXOs starting from the smallest one until the sum covers the amount
+//amounts are compared in fixed8 so float rounding can't make the sum of the UTXOs look smaller or bigger than it is
+//e.g. 0.1 + 0.7 is 0.7999999999999999 in float64
+func (s *ScriptBuilder) selectUTXOs(balance *Balance, amount float64) ([]UTXO, Fixed8, error) {
+	//no balance or a balance that was fetched but has no UTXOs
+	if balance == nil || len(balance.UTXOs) == 0 {
+		return nil, 0, fmt.Errorf("%w. Sending %v but only have 0", ErrInsufficientBalance, amount)
+	}
+	//sort min first
+	balance.SortMinFirst()
+	spendable := s.spendableUTXOs(balance)
+	if len(spendable) == 0 {
+		return nil, 0, fmt.Errorf("%w. Sending %v but no UTXO has enough confirmations", ErrInsufficientBalance, amount)
+	}
+	required := NewFixed8(amount)
+	total := Fixed8(0)
+	for _, utxo := range spendable {
+		total = total.Add(NewFixed8(utxo.Value))
+	}
+	if required > total {
+		return nil, 0, fmt.Errorf("%w. Sending %v but only have %v", ErrInsufficientBalance, amount, total)
+	}
+	selected := []UTXO{}
+	sum := Fixed8(0)
+	for index := 0; sum < required; index++ {
+		if index >= len(spendable) {
+			return nil, 0, fmt.Errorf("%w. Sending %v but only have %v", ErrInsufficientBalance, amount, sum)
+		}
+		selected = append(selected, spendable[index])
+		sum = sum.Add(NewFixed8(spendable[index].Value))
+	}
+	if s.MaxInputs > 0 && len(selected) > s.MaxInputs {
+		return s.selectLargestUTXOs(spendable, amount)
+	}
+	return selected, sum, nil
+}
 
-		returningAmount := runningFeeAmount - float64(feeAmount)
-		returningOutput := TransactionOutput{
-			Asset:   GAS,
-			Value:   int64(RoundFixed8(returningAmount) * float64(100000000)),
+//ErrTooManyInputs is returned when the amount can't be covered with MaxInputs UTXOs
+var ErrTooManyInputs = errors.New("too many inputs")
+
+//picks UTXOs starting from the largest one when the smallest first needs more than MaxInputs. spendable is sorted min first
+func (s *ScriptBuilder) selectLargestUTXOs(spendable []UTXO, amount float64) ([]UTXO, Fixed8, error) {
+	required := NewFixed8(amount)
+	selected := []UTXO{}
+	sum := Fixed8(0)
+	for index := len(spendable) - 1; index >= 0 && len(selected) < s.MaxInputs && sum < required; index-- {
+		selected = append(selected, spendable[index])
+		sum = sum.Add(NewFixed8(spendable[index].Value))
+	}
+	if sum < required {
+		return nil, 0, fmt.Errorf("%w. Sending %v needs more than %v inputs. send the balance to yourself first to consolidate the UTXOs", ErrTooManyInputs, amount, s.MaxInputs)
+	}
+	return selected, sum, nil
+}
+
+//selects the sender inputs for the asset and the fee payer GAS inputs for the network fee
+func (s *ScriptBuilder) selectSponsoredUTXOs(unspent Unspent, assetToSend NativeAsset, amountToSend float64, feePayerUnspent Unspent, networkFeeAmount NetworkFeeAmount) ([]UTXO, Fixed8, []UTXO, Fixed8, error) {
+	if networkFeeAmount <= 0 {
+		return nil, 0, nil, 0, fmt.Errorf("network fee must be more than zero when the fee is paid by another account")
+	}
+	sendingAsset := unspent.Assets[assetToSend]
+	if sendingAsset == nil {
+		return nil, 0, nil, 0, fmt.Errorf("Asset %v not found in UTXO", assetToSend)
+	}
+	inputs, sum, err := s.selectUTXOs(sendingAsset, amountToSend)
+	if err != nil {
+		return nil, 0, nil, 0, err
+	}
+	feeInputs, feeSum, err := s.selectUTXOs(feePayerUnspent.Assets[GAS], float64(networkFeeAmount))
+	if err != nil {
+		return nil, 0, nil, 0, fmt.Errorf("fee payer doesn't have enough balance for network fee.")
+	}
+	return inputs, sum, feeInputs, feeSum, nil
+}
+
+func (s *ScriptBuilder) GenerateTransactionInputWithFeePayer(unspent Unspent, assetToSend NativeAsset, amountToSend float64, feePayerUnspent Unspent, networkFeeAmount NetworkFeeAmount) ([]byte, error) {
+	inputs, _, feeInputs, _, err := s.selectSponsoredUTXOs(unspent, assetToSend, amountToSend, feePayerUnspent, networkFeeAmount)
+	if err != nil {
+		return nil, err
+	}
+	inputs = append(inputs, feeInputs...)
+
+	err = s.pushInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+	return s.ToBytes(), nil
+}
+
+func (s *ScriptBuilder) GenerateTransactionOutputWithFeePayer(sender NEOAddress, receiver NEOAddress, unspent Unspent, assetToSend NativeAsset, amountToSend float64, feePayer NEOAddress, feePayerUnspent Unspent, networkFeeAmount NetworkFeeAmount) ([]byte, error) {
+	_, sum, _, feeSum, err := s.selectSponsoredUTXOs(unspent, assetToSend, amountToSend, feePayerUnspent, networkFeeAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []TransactionOutput{
+		TransactionOutput{
+			Asset:   assetToSend,
+			Value:   NewFixed8(amountToSend),
+			Address: receiver,
+		},
+	}
+	//change of the asset goes back to the sender
+	if sum > NewFixed8(amountToSend) && s.isDust(assetToSend, sum.Sub(NewFixed8(amountToSend))) == false {
+		list = append(list, TransactionOutput{
+			Asset:   assetToSend,
+			Value:   sum.Sub(NewFixed8(amountToSend)),
 			Address: sender,
-		}
-		list = append(list, returningOutput)
+		})
+	}
+	//what is left after the network fee goes back to the fee payer
+	fee := NewFixed8(float64(networkFeeAmount))
+	if feeSum > fee && s.isDust(GAS, feeSum.Sub(fee)) == false {
+		list = append(list, TransactionOutput{
+			Asset:   GAS,
+			Value:   feeSum.Sub(fee),
+			Address: feePayer,
+		})
 	}
 
-	//number of outputs
-	s.pushLength(len(list))
-	for _, v := range list {
-		s.pushData(v)
+	if s.CheckMaximumAmount == true {
+		for _, v := range list {
+			err := v.Asset.ValidateAmount(v.Value.Float64())
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
+	err = s.pushOutputs(list)
+	if err != nil {
+		return nil, err
+	}
 	return s.ToBytes(), nil
 }
 
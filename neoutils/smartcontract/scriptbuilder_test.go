@@ -1,11 +1,16 @@
 package smartcontract_test
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/o3labs/neo-utils/neoutils/crypto"
 	"github.com/o3labs/neo-utils/neoutils/smartcontract"
 )
 
@@ -25,6 +30,40 @@ func TestNewScriptHash(t *testing.T) {
 	log.Printf("%v", s)
 }
 
+func TestScriptHashToExplorerString(t *testing.T) {
+	//RPX
+	scriptHash, err := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scriptHash.ToExplorerString() != "0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9" {
+		t.Fatalf("unexpected explorer string %v", scriptHash.ToExplorerString())
+	}
+	//the script hash stays little endian
+	if scriptHash.ToString() != "f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec" {
+		t.Fatalf("script hash changed to %v", scriptHash.ToString())
+	}
+}
+
+func TestAddressToScriptHash(t *testing.T) {
+	scriptHash, err := smartcontract.AddressToScriptHash("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := smartcontract.NewScriptHash("0x87cf67daa0c1e9b6caa1443cf5555b09cb3f8e5f")
+	if bytes.Equal(scriptHash, expected) == false {
+		t.Fatalf("expected %x got %x", expected, scriptHash)
+	}
+	if scriptHash.ToBigEndianString() != "87cf67daa0c1e9b6caa1443cf5555b09cb3f8e5f" {
+		t.Fatalf("unexpected big endian script hash %v", scriptHash.ToBigEndianString())
+	}
+
+	_, err = smartcontract.AddressToScriptHash("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgF")
+	if err == nil {
+		t.Fatal("expected an error for an invalid checksum")
+	}
+}
+
 func TestGenerateInvokeScript(t *testing.T) {
 	scriptHash, err := smartcontract.NewScriptHash("0x7cd338644833db2fd8824c410e364890d179e6f8")
 	if err != nil {
@@ -215,3 +254,644 @@ func TestGenerateTransactionOutput(t *testing.T) {
 	}
 	log.Printf("%x", b)
 }
+
+func TestGenerateTransactionOutputOverMaximumAmount(t *testing.T) {
+	s := &smartcontract.ScriptBuilder{RawBytes: []byte{}, CheckMaximumAmount: true}
+	neoTX1 := smartcontract.UTXO{
+		Index: 0,
+		TXID:  "e8b8bf4f98490368fc1caa86f8646e7383bb52751ffc3a1a7e296d715c4382ed",
+		Value: float64(1e18),
+	}
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{},
+	}
+	unspent.Assets[smartcontract.NEO] = &smartcontract.Balance{
+		Amount: float64(1e18),
+		UTXOs:  []smartcontract.UTXO{neoTX1},
+	}
+	sender := smartcontract.ParseNEOAddress("AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")
+	receiver := smartcontract.ParseNEOAddress("AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")
+
+	_, err := s.GenerateTransactionOutput(sender, receiver, unspent, smartcontract.NEO, float64(1e18), 0)
+	if err == nil {
+		t.Fail()
+		return
+	}
+	log.Printf("%v", err)
+}
+
+func TestValidateAmount(t *testing.T) {
+	if err := smartcontract.NEO.ValidateAmount(100000000); err != nil {
+		t.Fail()
+	}
+	if err := smartcontract.GAS.ValidateAmount(100000000.1); err == nil {
+		t.Fail()
+	}
+}
+
+func TestContractInvocationOverMaxItemSize(t *testing.T) {
+	scriptHash, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	args := []interface{}{make([]byte, 20), 1}
+
+	s := &smartcontract.ScriptBuilder{RawBytes: []byte{}, MaxItemSize: 10}
+	_, err := s.GenerateContractInvocationScriptWithError(scriptHash, "transfer", args)
+	if err == nil {
+		t.Fatal("expected an error for an argument over MaxItemSize")
+	}
+	if len(s.ToBytes()) != 0 {
+		t.Fatalf("expected nothing pushed got %x", s.ToBytes())
+	}
+	if s.GenerateContractInvocationScript(scriptHash, "transfer", args) != nil || s.GenerateContractInvocationData(scriptHash, "transfer", args) != nil {
+		t.Fatal("expected no script for an argument over MaxItemSize")
+	}
+	_, err = s.GenerateContractInvocationDataWithError(scriptHash, "transfer", args)
+	if err == nil {
+		t.Fatal("expected an error for an argument over MaxItemSize")
+	}
+
+	//nothing is written out before the script is complete
+	buffer := bytes.Buffer{}
+	streaming := &smartcontract.ScriptBuilder{RawBytes: []byte{}, MaxItemSize: 10, Writer: &buffer}
+	_, err = streaming.GenerateContractInvocationScriptWithError(scriptHash, "transfer", args)
+	if err == nil || buffer.Len() != 0 {
+		t.Fatalf("expected an error and nothing written got %v %x", err, buffer.Bytes())
+	}
+	expected := smartcontract.NewScriptBuilder().GenerateContractInvocationScript(scriptHash, "transfer", []interface{}{1})
+	b, err := streaming.GenerateContractInvocationScriptWithError(scriptHash, "transfer", []interface{}{1})
+	if err != nil || bytes.Equal(b, expected) == false || bytes.Equal(buffer.Bytes(), expected) == false {
+		t.Fatalf("expected %x got %x written %x %v", expected, b, buffer.Bytes(), err)
+	}
+}
+
+func TestPushDataOverMaxItemSize(t *testing.T) {
+	s := &smartcontract.ScriptBuilder{RawBytes: []byte{}, MaxItemSize: 1024}
+	err := s.Push(make([]byte, 2048))
+	if err == nil {
+		t.Fail()
+		return
+	}
+	log.Printf("%v", err)
+
+	//nested in an array
+	err = s.Push([]interface{}{1, make([]byte, 2048)})
+	if err == nil {
+		t.Fail()
+		return
+	}
+
+	err = s.Push(make([]byte, 1024))
+	if err != nil {
+		t.Fail()
+	}
+}
+
+func TestPushNEOAddressEqualsScriptHash(t *testing.T) {
+	contract, _ := smartcontract.NewScriptHash("ce575ae1bb6153330d20c560acb434dc5755241b")
+	address := smartcontract.ParseNEOAddress("AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")
+
+	//script hash of the address in big endian like the one from explorers
+	addressScriptHash, _ := smartcontract.NewScriptHash("e9eed8dc39332032dc22e5d6e86332c50327ba23")
+
+	withAddress := smartcontract.NewScriptBuilder()
+	withAddress.GenerateContractInvocationScript(contract, "transfer", []interface{}{address, address, 1})
+
+	withScriptHash := smartcontract.NewScriptBuilder()
+	withScriptHash.GenerateContractInvocationScript(contract, "transfer", []interface{}{[]byte(addressScriptHash), []byte(addressScriptHash), 1})
+
+	log.Printf("%x", withAddress.ToBytes())
+	if withAddress.FullHexString() != withScriptHash.FullHexString() {
+		t.Fail()
+		return
+	}
+	//PUSHBYTES20 + little endian script hash
+	if strings.Contains(withAddress.FullHexString(), "1423ba2703c53263e8d6e522dc32203339dcd8eee9") == false {
+		t.Fail()
+	}
+}
+
+func TestPushNEOAddressEqualsAddressToScriptHash(t *testing.T) {
+	address := "AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y"
+	scriptHash, err := smartcontract.AddressToScriptHash(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash160, _ := smartcontract.NewHash160(scriptHash.ToBigEndianString())
+
+	withAddress := smartcontract.NewScriptBuilder()
+	withAddress.Push(smartcontract.ParseNEOAddress(address))
+	withScriptHash := smartcontract.NewScriptBuilder()
+	withScriptHash.Push([]byte(scriptHash))
+	withHash160 := smartcontract.NewScriptBuilder()
+	withHash160.Push(hash160)
+
+	if withAddress.FullHexString() != withScriptHash.FullHexString() || withAddress.FullHexString() != withHash160.FullHexString() {
+		t.Fatalf("expected %v got %v and %v", withAddress.FullHexString(), withScriptHash.FullHexString(), withHash160.FullHexString())
+	}
+	if withAddress.FullHexString() != "1423ba2703c53263e8d6e522dc32203339dcd8eee9" {
+		t.Fatalf("unexpected push %v", withAddress.FullHexString())
+	}
+}
+
+func TestPushFullNEOAddressBytes(t *testing.T) {
+	//version + script hash + checksum
+	full, _ := crypto.Base58Decode("AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y")
+	s := smartcontract.NewScriptBuilder()
+	err := s.Push(smartcontract.NEOAddress(full))
+	if err != nil || s.FullHexString() != "1423ba2703c53263e8d6e522dc32203339dcd8eee9" {
+		t.Fail()
+		return
+	}
+
+	//version + script hash
+	s = smartcontract.NewScriptBuilder()
+	err = s.Push(smartcontract.NEOAddress(full[:21]))
+	if err != nil || s.FullHexString() != "1423ba2703c53263e8d6e522dc32203339dcd8eee9" {
+		t.Fail()
+		return
+	}
+
+	//bad checksum
+	full[len(full)-1] ^= 0xff
+	s = smartcontract.NewScriptBuilder()
+	err = s.Push(smartcontract.NEOAddress(full))
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestEmitFixedWidthInt(t *testing.T) {
+	value := big.NewInt(100000000) //0x05f5e100
+	expected := map[int]string{
+		8:  "0800e1f50500000000",
+		20: "1400e1f50500000000000000000000000000000000",
+		32: "2000e1f50500000000000000000000000000000000000000000000000000000000",
+	}
+	for width, e := range expected {
+		s := smartcontract.NewScriptBuilder()
+		err := s.EmitFixedWidthInt(value, width)
+		if err != nil {
+			log.Printf("%v", err)
+			t.Fail()
+			return
+		}
+		log.Printf("%v bytes %x", width, s.ToBytes())
+		if s.FullHexString() != e {
+			t.Fail()
+		}
+	}
+}
+
+func TestEmitFixedWidthIntNegativeAndOverflow(t *testing.T) {
+	s := smartcontract.NewScriptBuilder()
+	err := s.EmitFixedWidthInt(big.NewInt(-1), 4)
+	if err != nil || s.FullHexString() != "04ffffffff" {
+		t.Fail()
+		return
+	}
+
+	s = smartcontract.NewScriptBuilder()
+	err = s.EmitFixedWidthInt(big.NewInt(0x8000), 2)
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestGenerateInvokeScriptEmptyOperation(t *testing.T) {
+	scriptHash, _ := smartcontract.NewScriptHash("0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	s := smartcontract.NewScriptBuilder()
+	b := s.GenerateContractInvocationScript(scriptHash, "", []interface{}{1})
+
+	//PUSH1 PUSH1 PACK PUSH0 APPCALL [script hash]
+	expected := "5151c100" + "67" + "f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec"
+	if fmt.Sprintf("%x", b) != expected {
+		t.Fatalf("expected %v got %x", expected, b)
+	}
+}
+
+func TestScriptBuilderWithWriter(t *testing.T) {
+	push := func(sb smartcontract.ScriptBuilderInterface) {
+		sb.Push(17)
+		sb.Push([]byte(strings.Repeat("a", 300)))
+		sb.Push([]interface{}{true, 2})
+		sb.PushOpCode(smartcontract.NOP)
+		sb.EmitFixedWidthInt(big.NewInt(-2), 4)
+	}
+
+	inMemory := smartcontract.NewScriptBuilder()
+	push(inMemory)
+
+	buffer := bytes.Buffer{}
+	streaming := smartcontract.NewScriptBuilderWithWriter(&buffer)
+	push(streaming)
+
+	if bytes.Equal(buffer.Bytes(), inMemory.ToBytes()) == false {
+		t.Fatalf("expected %x got %x", inMemory.ToBytes(), buffer.Bytes())
+	}
+	//everything has been written out
+	if len(streaming.ToBytes()) != 0 {
+		t.Fail()
+	}
+}
+
+func TestPushInt(t *testing.T) {
+	cases := map[int]string{
+		-1:  "4f",
+		0:   "00",
+		16:  "60",
+		17:  "0111",
+		128: "028000",
+		-2:  "01fe",
+		300: "022c01",
+	}
+	for value, expected := range cases {
+		sb := smartcontract.NewScriptBuilder()
+		sb.Push(value)
+		if sb.FullHexString() != expected {
+			t.Fatalf("%v expected %v got %v", value, expected, sb.FullHexString())
+		}
+	}
+}
+
+//unspent holding the UTXOs of one asset. the UTXOs are copied because selecting inputs sorts them in place
+//so every call gives the list in the same order
+func unspentOf(asset smartcontract.NativeAsset, utxos ...smartcontract.UTXO) smartcontract.Unspent {
+	return smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			asset: &smartcontract.Balance{UTXOs: append([]smartcontract.UTXO{}, utxos...)},
+		},
+	}
+}
+
+//txid in the order it's serialized in an input
+func reversedHex(s string) string {
+	b, _ := hex.DecodeString(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return hex.EncodeToString(b)
+}
+
+func TestGenerateTransactionInputMinimumConfirmations(t *testing.T) {
+	unconfirmed := "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe"
+	confirmed := "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0"
+	utxos := []smartcontract.UTXO{
+		{Index: 0, TXID: unconfirmed, Value: 1, Confirmations: 0},
+		{Index: 0, TXID: confirmed, Value: 5, Confirmations: 3},
+	}
+
+	//the smallest UTXO is picked first when there is no minimum
+	b, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspentOf(smartcontract.NEO, utxos...), smartcontract.NEO, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprintf("%x", b) != "01"+reversedHex(unconfirmed)+"0000" {
+		t.Fatalf("unexpected inputs %x", b)
+	}
+
+	sb := &smartcontract.ScriptBuilder{RawBytes: []byte{}, MinimumConfirmations: 1}
+	b, err = sb.GenerateTransactionInput(unspentOf(smartcontract.NEO, utxos...), smartcontract.NEO, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprintf("%x", b) != "01"+reversedHex(confirmed)+"0000" {
+		t.Fatalf("unexpected inputs %x", b)
+	}
+
+	//only 5 NEO is confirmed
+	sb = &smartcontract.ScriptBuilder{RawBytes: []byte{}, MinimumConfirmations: 1}
+	_, err = sb.GenerateTransactionInput(unspentOf(smartcontract.NEO, utxos...), smartcontract.NEO, 6, 0)
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestPushArrayWithLargeElement(t *testing.T) {
+	large := bytes.Repeat([]byte{0xab}, 300)
+	sb := smartcontract.NewScriptBuilder()
+	err := sb.Push([]interface{}{large, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//elements are pushed in reverse. PUSH1 then PUSHDATA2 [300 in 2 bytes] [data] then PUSH2 PACK
+	expected := "51" + "4d" + "2c01" + hex.EncodeToString(large) + "52" + "c1"
+	if sb.FullHexString() != expected {
+		t.Fatalf("expected %v got %v", expected, sb.FullHexString())
+	}
+
+	//an array nested in an array
+	sb = smartcontract.NewScriptBuilder()
+	sb.Push([]interface{}{[]interface{}{large}})
+	expected = "4d" + "2c01" + hex.EncodeToString(large) + "51" + "c1" + "51" + "c1"
+	if sb.FullHexString() != expected {
+		t.Fatalf("expected %v got %v", expected, sb.FullHexString())
+	}
+}
+
+func TestPushDataLengthPrefix(t *testing.T) {
+	cases := map[int]string{
+		75:    "4b",
+		76:    "4c4c",
+		255:   "4cff",
+		256:   "4d0001",
+		65536: "4e00000100",
+	}
+	for length, prefix := range cases {
+		sb := smartcontract.NewScriptBuilder()
+		sb.Push(make([]byte, length))
+		if strings.HasPrefix(sb.FullHexString(), prefix) == false || len(sb.ToBytes()) != len(prefix)/2+length {
+			t.Fatalf("%v bytes expected prefix %v got %v", length, prefix, sb.FullHexString()[:10])
+		}
+	}
+}
+
+func TestPushEmptyArray(t *testing.T) {
+	scriptHash, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+
+	//neo-cli script for invoking operation "a" with a single empty array argument
+	//PUSH0 PACK (the empty array) PUSH1 PACK (the args) PUSHBYTES1 "a" APPCALL [script hash]
+	expected := "00c1" + "51c1" + "0161" + "67" + "f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec"
+
+	sb := smartcontract.NewScriptBuilder()
+	script := sb.GenerateContractInvocationScript(scriptHash, "a", []interface{}{smartcontract.EmptyArray})
+	if hex.EncodeToString(script) != expected {
+		t.Fatalf("expected %v got %x", expected, script)
+	}
+
+	sb = smartcontract.NewScriptBuilder()
+	script = sb.GenerateContractInvocationScript(scriptHash, "a", []interface{}{[]interface{}{}})
+	if hex.EncodeToString(script) != expected {
+		t.Fatalf("expected %v got %x", expected, script)
+	}
+}
+
+func TestPushZeroAndEmptyArray(t *testing.T) {
+	cases := []struct {
+		data     interface{}
+		expected string
+	}{
+		//integer 0 is PUSH0 alone
+		{0, "00"},
+		{false, "00"},
+		{nil, "00"},
+		//empty array is PUSH0 PACK
+		{[]interface{}{}, "00c1"},
+		{smartcontract.EmptyArray, "00c1"},
+		//[0, []] reversed. PUSH0 PACK for the empty array, PUSH0 for 0 then PUSH2 PACK
+		{[]interface{}{0, []interface{}{}}, "00c1" + "00" + "52c1"},
+		//[[0]] is PUSH0 PUSH1 PACK for the inner array then PUSH1 PACK
+		{[]interface{}{[]interface{}{0}}, "0051c1" + "51c1"},
+		//[[]] is PUSH0 PACK for the inner array then PUSH1 PACK
+		{[]interface{}{[]interface{}{}}, "00c1" + "51c1"},
+	}
+	for _, c := range cases {
+		sb := smartcontract.NewScriptBuilder()
+		err := sb.Push(c.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sb.FullHexString() != c.expected {
+			t.Fatalf("%#v: expected %v got %v", c.data, c.expected, sb.FullHexString())
+		}
+	}
+}
+
+func TestGenerateTransactionInputFloatRounding(t *testing.T) {
+	utxos := []smartcontract.UTXO{
+		{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 0.1},
+		{Index: 0, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 0.7},
+	}
+	//0.1 + 0.7 is 0.7999999999999999 in float64 but it's enough to send 0.8
+	b, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 0.8, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0x02 {
+		t.Fatalf("expected 2 inputs got %x", b)
+	}
+
+	//one fixed8 unit more than the balance
+	_, err = smartcontract.NewScriptBuilder().GenerateTransactionInput(unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 0.80000001, 0)
+	if errors.Is(err, smartcontract.ErrInsufficientBalance) == false {
+		t.Fatalf("expected ErrInsufficientBalance got %v", err)
+	}
+}
+
+func TestGenerateTransactionInputEmptyBalance(t *testing.T) {
+	//the balance was fetched but there is nothing to spend
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{UTXOs: []smartcontract.UTXO{}},
+			smartcontract.NEO: &smartcontract.Balance{},
+		},
+	}
+	_, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, smartcontract.GAS, 1, 0)
+	if errors.Is(err, smartcontract.ErrInsufficientBalance) == false {
+		t.Fatalf("expected ErrInsufficientBalance got %v", err)
+	}
+	_, err = smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, smartcontract.NEO, 1, 0)
+	if errors.Is(err, smartcontract.ErrInsufficientBalance) == false {
+		t.Fatalf("expected ErrInsufficientBalance got %v", err)
+	}
+}
+
+func TestGenerateTransactionOutputDustChange(t *testing.T) {
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1.00000005},
+				},
+			},
+		},
+	}
+	sender := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	receiver := smartcontract.ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+
+	//0.00000005 comes back as change
+	b, err := smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, smartcontract.GAS, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0x02 {
+		t.Fatalf("expected 2 outputs got %x", b)
+	}
+
+	//0.00000005 is under the threshold so it goes to the network fee
+	s := &smartcontract.ScriptBuilder{RawBytes: []byte{}, DustThreshold: 0.0001}
+	b, err = s.GenerateTransactionOutput(sender, receiver, unspent, smartcontract.GAS, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0x01 || len(b) != 1+60 {
+		t.Fatalf("expected 1 output got %x", b)
+	}
+	if bytes.Equal(b[1+32+8:], receiver) == false {
+		t.Fatalf("expected the output to the receiver got %x", b)
+	}
+}
+
+func TestGenerateMultiRecipientTransactionOutput(t *testing.T) {
+	utxos := []smartcontract.UTXO{
+		{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 10},
+	}
+	sender := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	addresses := []string{"AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2", "AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y", "AStZHy8E6StCqYQbzMqi4poH7YNDHQKxvt"}
+
+	build := func(order []int) []byte {
+		recipients := map[string]float64{}
+		for _, i := range order {
+			recipients[addresses[i]] = float64(i + 1)
+		}
+		b, err := smartcontract.NewScriptBuilder().GenerateMultiRecipientTransactionOutput(sender, recipients, unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 0.5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	first := build([]int{0, 1, 2})
+	for i := 0; i < 10; i++ {
+		again := build([]int{2, 0, 1})
+		if bytes.Equal(first, again) == false {
+			t.Fatalf("expected the same outputs got %x and %x", first, again)
+		}
+	}
+
+	//3 recipients and the change of 10 - 6 - 0.5
+	if first[0] != 0x04 {
+		t.Fatalf("expected 4 outputs got %x", first)
+	}
+	for i := 1; i < 3; i++ {
+		previous := first[1+(i-1)*60+40 : 1+i*60]
+		current := first[1+i*60+40 : 1+(i+1)*60]
+		if bytes.Compare(previous, current) >= 0 {
+			t.Fatalf("outputs are not sorted by script hash %x %x", previous, current)
+		}
+	}
+	if bytes.Equal(first[1+3*60+40:], sender) == false {
+		t.Fatalf("expected the change to the sender got %x", first[1+3*60+40:])
+	}
+	//the inputs pay the sum of the amounts and the fee and the change goes back
+	recipients := map[string]float64{addresses[0]: 1, addresses[1]: 2, addresses[2]: 3}
+	inputs, err := smartcontract.NewScriptBuilder().GenerateMultiRecipientTransactionInput(recipients, unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 6, 0.5)
+	if bytes.Equal(inputs, expected) == false {
+		t.Fatalf("expected %x got %x", expected, inputs)
+	}
+	err = smartcontract.ValidateInputsCoverOutputs(unspentOf(smartcontract.GAS, utxos...), inputs, first, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateTransactionInputMaxInputs(t *testing.T) {
+	utxos := []smartcontract.UTXO{}
+	for i := 0; i < 5; i++ {
+		utxos = append(utxos, smartcontract.UTXO{Index: i, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1})
+	}
+	utxos = append(utxos, smartcontract.UTXO{Index: 0, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 2})
+
+	//smallest first needs 4 inputs but the largest ones cover it with 3
+	sb := &smartcontract.ScriptBuilder{RawBytes: []byte{}, MaxInputs: 3}
+	b, err := sb.GenerateTransactionInput(unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 4, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0x03 {
+		t.Fatalf("expected 3 inputs got %x", b)
+	}
+
+	//6 GAS can't be covered with 3 inputs
+	sb = &smartcontract.ScriptBuilder{RawBytes: []byte{}, MaxInputs: 3}
+	_, err = sb.GenerateTransactionInput(unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 6, 0)
+	if errors.Is(err, smartcontract.ErrTooManyInputs) == false {
+		t.Fatalf("expected ErrTooManyInputs got %v", err)
+	}
+
+	//no limit
+	b, err = smartcontract.NewScriptBuilder().GenerateTransactionInput(unspentOf(smartcontract.GAS, utxos...), smartcontract.GAS, 6, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0x06 {
+		t.Fatalf("expected 6 inputs got %x", b)
+	}
+}
+
+func TestPushNilArgument(t *testing.T) {
+	sb := smartcontract.NewScriptBuilder()
+	err := sb.Push([]interface{}{[]byte{0xab}, nil, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//reversed. PUSH5, PUSH0 for nil, PUSHBYTES1 ab then PUSH3 PACK
+	expected := "55" + "00" + "01ab" + "53" + "c1"
+	if sb.FullHexString() != expected {
+		t.Fatalf("expected %v got %v", expected, sb.FullHexString())
+	}
+}
+
+func TestEmitRaw(t *testing.T) {
+	scriptHash, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	args := []interface{}{[]byte{0xab}, 5}
+
+	single := smartcontract.NewScriptBuilder()
+	single.Push(args)
+	single.Push([]byte("balanceOf"))
+	single.PushOpCode(smartcontract.APPCALL)
+	single.Push(scriptHash)
+
+	//args built separately then spliced in
+	argsFragment := smartcontract.NewScriptBuilder()
+	argsFragment.Push(args)
+	callFragment := smartcontract.NewScriptBuilder()
+	callFragment.Push([]byte("balanceOf"))
+	callFragment.PushOpCode(smartcontract.APPCALL)
+	callFragment.Push(scriptHash)
+
+	combined := smartcontract.NewScriptBuilder()
+	combined.EmitRaw(argsFragment.ToBytes())
+	combined.EmitRaw(callFragment.ToBytes())
+
+	if combined.FullHexString() != single.FullHexString() {
+		t.Fatalf("expected %v got %v", single.FullHexString(), combined.FullHexString())
+	}
+}
+
+func TestPushBytesSameAsHexString(t *testing.T) {
+	for _, length := range []int{0, 1, 75, 76, 255, 256, 65535, 65536} {
+		b := bytes.Repeat([]byte{0xab}, length)
+		fromBytes := smartcontract.NewScriptBuilder()
+		err := fromBytes.Push(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromHex := smartcontract.NewScriptBuilder()
+		err = fromHex.Push(hex.EncodeToString(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(fromBytes.ToBytes(), fromHex.ToBytes()) == false {
+			t.Fatalf("%v bytes pushed differently %x and %x", length, fromBytes.ToBytes()[:8], fromHex.ToBytes()[:8])
+		}
+	}
+}
+
+func BenchmarkPushBytes(b *testing.B) {
+	data := bytes.Repeat([]byte{0xab}, 1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		smartcontract.NewScriptBuilder().Push(data)
+	}
+}
+
+//the same data pushed as a hex string is decoded first. this is what []byte used to go through
+func BenchmarkPushHexString(b *testing.B) {
+	data := hex.EncodeToString(bytes.Repeat([]byte{0xab}, 1024))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		smartcontract.NewScriptBuilder().Push(data)
+	}
+}
@@ -0,0 +1,80 @@
+package smartcontract
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+//RequiredSigners returns the script hashes that must provide a witness for the transaction sorted the same as neo-cli.
+//they are the owners of the inputs, the owners of the claimed outputs of a claim transaction and every Script attribute.
+//the transaction itself only has a reference to the output so its Address comes from resolver.
+//resolver can be nil when the transaction has no input or claim
+func RequiredSigners(tx *Transaction, resolver OutputResolver) ([]ScriptHash, error) {
+	references := [][]byte{}
+	inputs, err := readFixedLengthSection(tx.Inputs, transactionInputLength)
+	if err != nil {
+		return nil, err
+	}
+	references = append(references, inputs...)
+	if tx.Type == ClaimTransaction {
+		claims, err := readFixedLengthSection(tx.Data, transactionInputLength)
+		if err != nil {
+			return nil, err
+		}
+		references = append(references, claims...)
+	}
+
+	signers := map[string]ScriptHash{}
+	for _, item := range references {
+		txID := hex.EncodeToString(reverseBytes(append([]byte{}, item[:32]...)))
+		index := int(binary.LittleEndian.Uint16(item[32:]))
+		if resolver == nil {
+			return nil, fmt.Errorf("need the owner of input %v:%v", txID, index)
+		}
+		output, err := resolver(txID, index)
+		if err != nil {
+			return nil, err
+		}
+		owner := ScriptHash(output.Address)
+		if len(owner) != Uint160Length {
+			return nil, fmt.Errorf("invalid owner %x of input %v:%v", []byte(owner), txID, index)
+		}
+		signers[string(owner)] = owner
+	}
+
+	attributes, err := tx.ParseAttributes()
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range attributes {
+		if attribute.Usage == Script {
+			signers[string(attribute.Data)] = ScriptHash(attribute.Data)
+		}
+	}
+
+	list := []ScriptHash{}
+	for _, signer := range signers {
+		list = append(list, signer)
+	}
+	//UInt160 compares from the last byte
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(reverseBytes(append([]byte{}, list[i]...)), reverseBytes(append([]byte{}, list[j]...))) < 0
+	})
+	return list, nil
+}
+
+//[var int count] + N x item of a section on its own
+func readFixedLengthSection(b []byte, itemLength int) ([][]byte, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	r := newBinaryReader(b)
+	items := readFixedLengthItems(r, itemLength)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return items, nil
+}
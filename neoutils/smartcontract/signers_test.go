@@ -0,0 +1,70 @@
+package smartcontract_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestRequiredSigners(t *testing.T) {
+	first, _ := smartcontract.AddressToScriptHash("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	second, _ := smartcontract.AddressToScriptHash("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+	contract, _ := smartcontract.NewScriptHash("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+
+	utxos := []smartcontract.UTXO{
+		{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1},
+		{Index: 1, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 2},
+		{Index: 2, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 3},
+	}
+	owners := map[string]smartcontract.ScriptHash{
+		"1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe:0": first,
+		"ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0:1": second,
+		"ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0:2": first,
+	}
+	resolver := func(txID string, index int) (smartcontract.TransactionOutput, error) {
+		owner, ok := owners[fmt.Sprintf("%v:%v", txID, index)]
+		if ok == false {
+			return smartcontract.TransactionOutput{}, fmt.Errorf("unknown input %v:%v", txID, index)
+		}
+		return smartcontract.TransactionOutput{Asset: smartcontract.GAS, Address: smartcontract.NEOAddress(owner)}, nil
+	}
+
+	tx := smartcontract.NewContractTransaction()
+	inputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInputFromUTXOs(utxos, smartcontract.GAS, 6, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Inputs = inputs
+	tx.Outputs = []byte{0x00}
+	tx.Attributes, err = smartcontract.NewScriptBuilder().GenerateTransactionAttributes(map[smartcontract.TransactionAttribute][]byte{smartcontract.Script: contract})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers, err := smartcontract.RequiredSigners(&tx, resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//each owner once plus the Script attribute
+	if len(signers) != 3 {
+		t.Fatalf("expected 3 signers got %x", signers)
+	}
+	for _, expected := range []smartcontract.ScriptHash{first, second, contract} {
+		found := false
+		for _, signer := range signers {
+			if bytes.Equal(signer, expected) {
+				found = true
+			}
+		}
+		if found == false {
+			t.Fatalf("expected %x in %x", expected, signers)
+		}
+	}
+
+	_, err = smartcontract.RequiredSigners(&tx, nil)
+	if err == nil {
+		t.Fatal("expected an error without a resolver for the inputs")
+	}
+}
@@ -0,0 +1,105 @@
+package smartcontract
+
+import (
+	"fmt"
+)
+
+type StateType byte
+
+const (
+	AccountStateType   StateType = 0x40
+	ValidatorStateType StateType = 0x48
+)
+
+const (
+	stateDescriptorMaxKeyLength   = 100
+	stateDescriptorMaxFieldLength = 32
+	stateDescriptorMaxValueLength = 65535
+)
+
+//StateDescriptor is the exclusive data of a StateTransaction.
+//Account/Votes is used to vote for validators and Validator/Registered to register a validator
+//https://github.com/neo-project/neo/blob/master/neo/Core/StateDescriptor.cs
+type StateDescriptor struct {
+	Type  StateType
+	Key   []byte
+	Field string
+	Value []byte
+}
+
+//NewAccountVotesDescriptor votes for the validators with the NEO held by the account
+func NewAccountVotesDescriptor(account NEOAddress, validators [][]byte) (StateDescriptor, error) {
+	scriptHash, err := account.scriptHashBytes()
+	if err != nil {
+		return StateDescriptor{}, err
+	}
+	//value is an array of public keys
+	value := varIntBytes(uint64(len(validators)))
+	for _, pb := range validators {
+		if len(pb) != publicKeyLength {
+			return StateDescriptor{}, fmt.Errorf("invalid validator public key %x", pb)
+		}
+		value = append(value, pb...)
+	}
+	return StateDescriptor{
+		Type:  AccountStateType,
+		Key:   scriptHash,
+		Field: "Votes",
+		Value: value,
+	}, nil
+}
+
+//NewValidatorRegisteredDescriptor registers or unregisters the public key as a validator
+func NewValidatorRegisteredDescriptor(publicKey []byte, registered bool) (StateDescriptor, error) {
+	if len(publicKey) != publicKeyLength {
+		return StateDescriptor{}, fmt.Errorf("invalid validator public key %x", publicKey)
+	}
+	value := []byte{0x00}
+	if registered == true {
+		value = []byte{0x01}
+	}
+	return StateDescriptor{
+		Type:  ValidatorStateType,
+		Key:   publicKey,
+		Field: "Registered",
+		Value: value,
+	}, nil
+}
+
+//[type(1)] + [var int key length] + [key] + [var int field length] + [field] + [var int value length] + [value]
+func (d StateDescriptor) ToBytes() ([]byte, error) {
+	if d.Type != AccountStateType && d.Type != ValidatorStateType {
+		return nil, fmt.Errorf("invalid state type 0x%02x", byte(d.Type))
+	}
+	if len(d.Key) > stateDescriptorMaxKeyLength {
+		return nil, fmt.Errorf("key is %v bytes. maximum is %v bytes", len(d.Key), stateDescriptorMaxKeyLength)
+	}
+	if len(d.Field) > stateDescriptorMaxFieldLength {
+		return nil, fmt.Errorf("field is %v bytes. maximum is %v bytes", len(d.Field), stateDescriptorMaxFieldLength)
+	}
+	if len(d.Value) > stateDescriptorMaxValueLength {
+		return nil, fmt.Errorf("value is %v bytes. maximum is %v bytes", len(d.Value), stateDescriptorMaxValueLength)
+	}
+	b := []byte{byte(d.Type)}
+	b = append(b, varIntBytes(uint64(len(d.Key)))...)
+	b = append(b, d.Key...)
+	b = append(b, varIntBytes(uint64(len(d.Field)))...)
+	b = append(b, []byte(d.Field)...)
+	b = append(b, varIntBytes(uint64(len(d.Value)))...)
+	b = append(b, d.Value...)
+	return b, nil
+}
+
+//GenerateStateTransactionData returns the exclusive data of a StateTransaction
+//[var int number of descriptors] + N x descriptor
+func GenerateStateTransactionData(descriptors []StateDescriptor) ([]byte, error) {
+	data := varIntBytes(uint64(len(descriptors)))
+	for _, d := range descriptors {
+		b, err := d.ToBytes()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, b...)
+	}
+	return data, nil
+}
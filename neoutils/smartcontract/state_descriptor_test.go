@@ -0,0 +1,59 @@
+package smartcontract_test
+
+import (
+	"encoding/hex"
+	"log"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestAccountVotesDescriptor(t *testing.T) {
+	validators := [][]byte{}
+	for _, v := range []string{
+		"02486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a70",
+		"024c7b7fb6c310fccf1ba33b082519d82964ea93868d676662d4a59ad548df0e7d",
+	} {
+		b, _ := hex.DecodeString(v)
+		validators = append(validators, b)
+	}
+	account := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	descriptor, err := smartcontract.NewAccountVotesDescriptor(account, validators)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := smartcontract.GenerateStateTransactionData([]smartcontract.StateDescriptor{descriptor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Printf("%x", data)
+	expected := "01" + //number of descriptors
+		"40" + //Account
+		"14" + "5f8e3fcb095b55f53c44a1cab6e9c1a0da67cf87" + //script hash
+		"05" + "566f746573" + //Votes
+		"43" + "02" + //value length + number of public keys
+		"02486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a70" +
+		"024c7b7fb6c310fccf1ba33b082519d82964ea93868d676662d4a59ad548df0e7d"
+	if hex.EncodeToString(data) != expected {
+		t.Fatalf("expected %v got %x", expected, data)
+	}
+
+	tx := smartcontract.NewStateTransaction()
+	tx.Data = data
+	if tx.ToBytes()[0] != byte(smartcontract.StateTransaction) {
+		t.Fail()
+	}
+}
+
+func TestStateDescriptorFieldTooLong(t *testing.T) {
+	descriptor := smartcontract.StateDescriptor{
+		Type:  smartcontract.AccountStateType,
+		Key:   make([]byte, 20),
+		Field: "ThisFieldNameIsLongerThanThirtyTwoBytes",
+	}
+	_, err := descriptor.ToBytes()
+	if err == nil {
+		t.Fail()
+	}
+}
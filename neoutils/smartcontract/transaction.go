@@ -2,7 +2,10 @@ package smartcontract
 
 import (
 	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 )
 
 type Transaction struct {
@@ -18,26 +21,20 @@ type Transaction struct {
 
 type TransactionOutput struct {
 	Asset   NativeAsset
-	Value   int64
+	Value   Fixed8
 	Address NEOAddress
 }
 
 func (t *Transaction) ToBytes() []byte {
-	payload := []byte{}
-	payload = append(payload, byte(t.Type))
-	payload = append(payload, byte(t.Version))
-	payload = append(payload, t.Data...)
-	payload = append(payload, t.Attributes...)
-	payload = append(payload, t.Inputs...)
-	payload = append(payload, t.Outputs...)
+	payload := t.UnsignedBytes()
 	payload = append(payload, t.Script...)
 
 	return payload
 }
 
-//this ToHash256 returns little endian bytes.
-//TXID is big endian bytes, so when calling json-rpc api we need to reverse it
-func (t *Transaction) ToHash256() []byte {
+//UnsignedBytes returns the transaction without the witnesses. this is what gets hashed for both the txid and the signatures
+//so every signer of a transaction must sign these exact bytes
+func (t *Transaction) UnsignedBytes() []byte {
 	payload := []byte{}
 	payload = append(payload, byte(t.Type))
 	payload = append(payload, byte(t.Version))
@@ -45,8 +42,48 @@ func (t *Transaction) ToHash256() []byte {
 	payload = append(payload, t.Attributes...)
 	payload = append(payload, t.Inputs...)
 	payload = append(payload, t.Outputs...)
+	return payload
+}
 
-	hash := sha256.Sum256(payload)
+//WriteTo writes the same bytes as ToBytes to w without putting the whole transaction together first
+//e.g. into a sha256 hasher or a network connection
+func (t *Transaction) WriteTo(w io.Writer) (int64, error) {
+	total := int64(0)
+	for _, part := range [][]byte{{byte(t.Type), byte(t.Version)}, t.Data, t.Attributes, t.Inputs, t.Outputs, t.Script} {
+		n, err := w.Write(part)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+var _ io.WriterTo = (*Transaction)(nil)
+
+//ContractTransactionSigningData returns the bytes neo-cli signs for a ContractTransaction (GetHashData).
+//[type 0x80] + [version 0x00] + attributes + inputs + outputs without the witnesses.
+//each section is already prefixed with its var int count the way GenerateTransactionAttributes, GenerateTransactionInput and GenerateTransactionOutput return it
+func ContractTransactionSigningData(attributes []byte, inputs []byte, outputs []byte) []byte {
+	tx := NewContractTransaction()
+	tx.Attributes = attributes
+	tx.Inputs = inputs
+	tx.Outputs = outputs
+	return tx.UnsignedBytes()
+}
+
+//SigningHash returns sha256 of the unsigned transaction including attributes, inputs and outputs.
+//this is the hash the signature is made over. the signer hashes the unsigned bytes once so it's not ToHash256
+func (t *Transaction) SigningHash() []byte {
+	hash := sha256.Sum256(t.UnsignedBytes())
+	return hash[:]
+}
+
+//this ToHash256 returns little endian bytes.
+//TXID is big endian bytes, so when calling json-rpc api we need to reverse it
+//the hash is over the unsigned transaction the same as neo-cli so attaching the witnesses doesn't change the txid
+func (t *Transaction) ToHash256() []byte {
+	hash := sha256.Sum256(t.UnsignedBytes())
 	hash = sha256.Sum256(hash[:])
 
 	return hash[:]
@@ -56,6 +93,30 @@ func (t *Transaction) ToTXID() string {
 	return fmt.Sprintf("%x", reverseBytes(t.ToHash256()))
 }
 
+//MarshalJSON dumps each section of the transaction in hex together with the txid and the type name
+//this is meant for logging and inspecting a built transaction
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TXID       string `json:"txid"`
+		Type       string `json:"type"`
+		Version    int    `json:"version"`
+		Data       string `json:"data"`
+		Attributes string `json:"attributes"`
+		Inputs     string `json:"inputs"`
+		Outputs    string `json:"outputs"`
+		Script     string `json:"script"`
+	}{
+		TXID:       t.ToTXID(),
+		Type:       t.Type.String(),
+		Version:    int(t.Version),
+		Data:       hex.EncodeToString(t.Data),
+		Attributes: hex.EncodeToString(t.Attributes),
+		Inputs:     hex.EncodeToString(t.Inputs),
+		Outputs:    hex.EncodeToString(t.Outputs),
+		Script:     hex.EncodeToString(t.Script),
+	})
+}
+
 //version is 0 currently
 //it needs to change to 1 eventually to support pay gas to run smart contract
 //https://github.com/neo-project/neo/blob/11d8db11568d9eadeeb86c5b8c21a1d3937e0912/neo/Core/InvocationTransaction.cs#L23
@@ -66,9 +127,42 @@ func NewInvocationTransaction() Transaction {
 	}
 }
 
+//NewInvocationTransactionWithGas is a version 1 invocation transaction that pays gas to run the script.
+//Data is generated by GenerateInvocationTransactionData
+func NewInvocationTransactionWithGas() Transaction {
+	return Transaction{
+		Type:    InvocationTransaction,
+		Version: NEOTradingVersionPayableGAS,
+	}
+}
+
 func NewContractTransaction() Transaction {
 	return Transaction{
 		Type:    ContractTransaction,
 		Version: NEOTradingVersion,
 	}
 }
+
+//Data of a claim transaction is generated by GenerateClaimTransactionData
+func NewClaimTransaction() Transaction {
+	return Transaction{
+		Type:    ClaimTransaction,
+		Version: NEOTradingVersion,
+	}
+}
+
+//Data of an enrollment transaction is generated by GenerateEnrollmentTransactionData
+func NewEnrollmentTransaction() Transaction {
+	return Transaction{
+		Type:    EnrollmentTransaction,
+		Version: NEOTradingVersion,
+	}
+}
+
+//Data of a state transaction is generated by GenerateStateTransactionData
+func NewStateTransaction() Transaction {
+	return Transaction{
+		Type:    StateTransaction,
+		Version: NEOTradingVersion,
+	}
+}
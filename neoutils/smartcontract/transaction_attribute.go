@@ -1,5 +1,10 @@
 package smartcontract
 
+import (
+	"fmt"
+	"sort"
+)
+
 type TransactionAttribute byte
 
 const (
@@ -47,9 +52,159 @@ const (
 	Remark12 TransactionAttribute = 0xfc
 	Remark13 TransactionAttribute = 0xfd
 	Remark14 TransactionAttribute = 0xfe
-	Remark15 TransactionAttribute = 0xf
+	Remark15 TransactionAttribute = 0xff
 )
 
 func (t TransactionAttribute) ToByte() byte {
 	return byte(t)
 }
+
+const (
+	maxDescriptionUrlLength = 255
+	maxDescriptionLength    = 65535
+)
+
+//TransactionAttributes is usage -> data. it can be passed to GenerateTransactionAttributes as is
+type TransactionAttributes map[TransactionAttribute][]byte
+
+//TransactionAttributeItem is an attribute read back from a transaction
+type TransactionAttributeItem struct {
+	Usage TransactionAttribute
+	Data  []byte
+}
+
+//WithDescriptionURL attaches a URL of at most 255 bytes e.g. a link to the order on an exchange
+func (a TransactionAttributes) WithDescriptionURL(url string) error {
+	err := validateAttributeData(DescriptionUrl, []byte(url))
+	if err != nil {
+		return err
+	}
+	a[DescriptionUrl] = []byte(url)
+	return nil
+}
+
+//WithDescription attaches arbitrary data of at most 65535 bytes e.g. a memo or an order id
+func (a TransactionAttributes) WithDescription(description []byte) error {
+	err := validateAttributeData(Description, description)
+	if err != nil {
+		return err
+	}
+	a[Description] = description
+	return nil
+}
+
+//WithAttribute attaches data with any usage. NEO 2 has no lock time attribute so this is the way to attach
+//attributes without a typed helper. the length is checked against the usage
+//hashes and ECDH keys are 32 bytes, Script is 20 bytes, DescriptionUrl is at most 255 bytes and Description and Remark at most 65535 bytes
+func (a TransactionAttributes) WithAttribute(usage TransactionAttribute, data []byte) error {
+	err := validateAttributeData(usage, data)
+	if err != nil {
+		return err
+	}
+	a[usage] = data
+	return nil
+}
+
+func isHashAttribute(usage TransactionAttribute) bool {
+	return usage == ContractHash || usage == Vote || (usage >= Hash1 && usage <= Hash15)
+}
+
+//ECDH02 and ECDH03 carry a compressed public key. the usage is the 0x02 or 0x03 prefix of the key so only the 32 bytes X coordinate is serialized
+func isECDHAttribute(usage TransactionAttribute) bool {
+	return usage == ECDH02 || usage == ECDH03
+}
+
+func isRemarkAttribute(usage TransactionAttribute) bool {
+	return usage >= Remark
+}
+
+func validateAttributeData(usage TransactionAttribute, data []byte) error {
+	switch {
+	case isHashAttribute(usage), isECDHAttribute(usage):
+		if len(data) != 32 {
+			return fmt.Errorf("attribute 0x%02x must be 32 bytes but it's %v bytes", byte(usage), len(data))
+		}
+	case usage == Script:
+		if len(data) != Uint160Length {
+			return fmt.Errorf("attribute 0x%02x must be %v bytes but it's %v bytes", byte(usage), Uint160Length, len(data))
+		}
+	case usage == DescriptionUrl:
+		if len(data) > maxDescriptionUrlLength {
+			return fmt.Errorf("attribute 0x%02x is %v bytes. maximum is %v bytes", byte(usage), len(data), maxDescriptionUrlLength)
+		}
+	case usage == Description || isRemarkAttribute(usage):
+		if len(data) > maxDescriptionLength {
+			return fmt.Errorf("attribute 0x%02x is %v bytes. maximum is %v bytes", byte(usage), len(data), maxDescriptionLength)
+		}
+	default:
+		return fmt.Errorf("unsupported attribute usage 0x%02x", byte(usage))
+	}
+	return nil
+}
+
+//serialized data of an attribute without the usage byte
+//hashes, ECDH keys and script are fixed length, DescriptionUrl is prefixed with one byte length and the rest with var int length
+//https://github.com/neo-project/neo/blob/master/neo/Network/P2P/Payloads/TransactionAttribute.cs
+func attributeDataBytes(usage TransactionAttribute, data []byte) ([]byte, error) {
+	err := validateAttributeData(usage, data)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case isHashAttribute(usage), isECDHAttribute(usage), usage == Script:
+		return data, nil
+	case usage == DescriptionUrl:
+		return append([]byte{byte(len(data))}, data...), nil
+	}
+	return append(varIntBytes(uint64(len(data))), data...), nil
+}
+
+func readAttributeData(r *binaryReader, usage TransactionAttribute) []byte {
+	switch {
+	case isHashAttribute(usage), isECDHAttribute(usage):
+		return r.readBytes(32)
+	case usage == Script:
+		return r.readBytes(Uint160Length)
+	case usage == DescriptionUrl:
+		return r.readBytes(int(r.readByte()))
+	case usage == Description || isRemarkAttribute(usage):
+		return r.readVarBytes(maxDescriptionLength)
+	}
+	r.fail(fmt.Errorf("unsupported attribute usage 0x%02x", byte(usage)))
+	return nil
+}
+
+//ParseTransactionAttributes reads the attributes section of a transaction
+//[var int count] + N x ([usage(1)] + [data])
+func ParseTransactionAttributes(b []byte) ([]TransactionAttributeItem, error) {
+	r := newBinaryReader(b)
+	items := readTransactionAttributes(r)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("unexpected %v bytes after attributes", r.remaining())
+	}
+	return items, nil
+}
+
+func readTransactionAttributes(r *binaryReader) []TransactionAttributeItem {
+	count := r.readVarInt()
+	items := []TransactionAttributeItem{}
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		usage := TransactionAttribute(r.readByte())
+		data := readAttributeData(r, usage)
+		items = append(items, TransactionAttributeItem{Usage: usage, Data: data})
+	}
+	return items
+}
+
+//attribute usages in ascending order so the same attributes always produce the same transaction
+func sortedAttributeUsages(attributes map[TransactionAttribute][]byte) []TransactionAttribute {
+	usages := []TransactionAttribute{}
+	for k := range attributes {
+		usages = append(usages, k)
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i] < usages[j] })
+	return usages
+}
@@ -1,8 +1,66 @@
 package smartcontract
 
+import (
+	"fmt"
+)
+
 //naming base on NEO network protocol
 //http://docs.neo.org/en-us/network/network-protocol.html
 type TransactionValidationScript struct {
 	StackScript  []byte
 	RedeemScript []byte
 }
+
+//maximum size of each script in a witness
+const maxWitnessScriptSize = 65536
+
+//InvocationScript pushes the signatures onto the stack
+func (t TransactionValidationScript) InvocationScript() []byte {
+	return t.StackScript
+}
+
+//VerificationScript checks the signatures e.g. [public key] CHECKSIG
+func (t TransactionValidationScript) VerificationScript() []byte {
+	return t.RedeemScript
+}
+
+//SerializeTransactionScripts writes the scripts section of a transaction in the same format ParseTransactionScripts reads
+//an empty list is written as 0x00 so an unsigned transaction is still valid on the wire
+func SerializeTransactionScripts(scripts []TransactionValidationScript) []byte {
+	s := &ScriptBuilder{RawBytes: varIntBytes(uint64(len(scripts)))}
+	for _, script := range scripts {
+		s.pushData(script)
+	}
+	return s.ToBytes()
+}
+
+//SetWitnesses replaces the scripts of the transaction with the witnesses
+func (t *Transaction) SetWitnesses(witnesses []TransactionValidationScript) {
+	t.Script = SerializeTransactionScripts(witnesses)
+}
+
+//ParseTransactionScripts reads the scripts section of a transaction
+//[var int count] + N x ([var int length] + [invocation script] + [var int length] + [verification script])
+func ParseTransactionScripts(b []byte) ([]TransactionValidationScript, error) {
+	r := newBinaryReader(b)
+	scripts := readTransactionScripts(r)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("unexpected %v bytes after scripts", r.remaining())
+	}
+	return scripts, nil
+}
+
+func readTransactionScripts(r *binaryReader) []TransactionValidationScript {
+	count := r.readVarInt()
+	scripts := []TransactionValidationScript{}
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		script := TransactionValidationScript{}
+		script.StackScript = r.readVarBytes(maxWitnessScriptSize)
+		script.RedeemScript = r.readVarBytes(maxWitnessScriptSize)
+		scripts = append(scripts, script)
+	}
+	return scripts
+}
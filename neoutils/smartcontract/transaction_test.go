@@ -1,5 +1,16 @@
 package smartcontract
 
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/btckey"
+)
+
 // import (
 // 	"log"
 // 	"testing"
@@ -100,3 +111,230 @@ package smartcontract
 // 	}
 // 	return b
 // }
+
+func TestTransactionMarshalJSON(t *testing.T) {
+	tx := NewContractTransaction()
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+
+	b, err := json.Marshal(tx)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	log.Printf("%s", b)
+
+	//marshaling twice must give the same result
+	again, _ := json.Marshal(&tx)
+	if string(b) != string(again) {
+		t.Fail()
+		return
+	}
+
+	result := map[string]interface{}{}
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		t.Fail()
+		return
+	}
+	if result["type"] != "ContractTransaction" || result["txid"] != tx.ToTXID() || result["inputs"] != "00" {
+		t.Fail()
+	}
+}
+
+func TestSigningHashCoversAttributes(t *testing.T) {
+	generate := func(remark string) Transaction {
+		tx := NewInvocationTransaction()
+		tx.Data = NewScriptBuilder().GenerateContractInvocationData(ScriptHash(make([]byte, 20)), "name", nil)
+		attributes, err := NewScriptBuilder().GenerateTransactionAttributes(map[TransactionAttribute][]byte{Remark: []byte(remark)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.Attributes = attributes
+		tx.Inputs = []byte{0x00}
+		tx.Outputs = []byte{0x00}
+		return tx
+	}
+	first := generate("first")
+	second := generate("second")
+
+	if hex.EncodeToString(first.SigningHash()) == hex.EncodeToString(second.SigningHash()) {
+		t.Fatal("transactions with different remarks must have different signing hashes")
+	}
+	unsigned := first.ToBytes()
+	hash := sha256.Sum256(unsigned)
+	if hex.EncodeToString(first.SigningHash()) != hex.EncodeToString(hash[:]) {
+		t.Fatalf("signing hash must be sha256 of the whole unsigned transaction")
+	}
+
+	privateKey := "7d128a6d096f0c14c3a25a2b0c41cf79661bfcb4a8cc95aaaea28bde4d732344"
+	firstSignature, _ := btckey.Sign(first.ToBytes(), privateKey)
+	secondSignature, _ := btckey.Sign(second.ToBytes(), privateKey)
+	if hex.EncodeToString(firstSignature) == hex.EncodeToString(secondSignature) {
+		t.Fatal("transactions with different remarks must have different signatures")
+	}
+}
+
+func TestUnsignedBytes(t *testing.T) {
+	tx := NewContractTransaction()
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x01}
+	tx.Outputs = []byte{0x02}
+
+	first := tx.UnsignedBytes()
+	second := tx.UnsignedBytes()
+	if hex.EncodeToString(first) != hex.EncodeToString(second) {
+		t.Fatalf("expected identical bytes got %x and %x", first, second)
+	}
+	if hex.EncodeToString(first) != "8000000102" {
+		t.Fatalf("unexpected unsigned bytes %x", first)
+	}
+
+	//a witness doesn't change the unsigned bytes
+	tx.Script = []byte{0x01, 0x00, 0x00}
+	if hex.EncodeToString(tx.UnsignedBytes()) != hex.EncodeToString(first) {
+		t.Fatalf("unsigned bytes must not include the witnesses got %x", tx.UnsignedBytes())
+	}
+}
+
+func TestTXIDUnchangedBySigning(t *testing.T) {
+	tx := NewInvocationTransaction()
+	tx.Data = NewScriptBuilder().GenerateContractInvocationData(ScriptHash(make([]byte, 20)), "name", nil)
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	unsignedTXID := tx.ToTXID()
+
+	privateKey := "7d128a6d096f0c14c3a25a2b0c41cf79661bfcb4a8cc95aaaea28bde4d732344"
+	signedData, err := btckey.Sign(tx.UnsignedBytes(), privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, _ := hex.DecodeString("02e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a8986")
+	tx.Script = NewScriptBuilder().GenerateVerificationScripts([]interface{}{TransactionSignature{SignedData: signedData, PublicKey: publicKey}})
+
+	if tx.ToTXID() != unsignedTXID {
+		t.Fatalf("expected %v got %v after attaching the witness", unsignedTXID, tx.ToTXID())
+	}
+	//the txid read back from the signed transaction is the same too
+	deserialized, err := DeserializeTransaction(tx.ToBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deserialized.ToTXID() != unsignedTXID {
+		t.Fatalf("expected %v got %v", unsignedTXID, deserialized.ToTXID())
+	}
+
+	//hashing the signed form gives a different hash
+	hash := sha256.Sum256(tx.ToBytes())
+	hash = sha256.Sum256(hash[:])
+	if hex.EncodeToString(reverseBytes(hash[:])) == unsignedTXID {
+		t.Fatal("txid must not include the witnesses")
+	}
+}
+
+func TestTransactionWriteTo(t *testing.T) {
+	tx := NewInvocationTransaction()
+	tx.Data = NewScriptBuilder().GenerateContractInvocationData(ScriptHash(make([]byte, 20)), "name", nil)
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+
+	//the unsigned transaction piped into the hasher gives the txid
+	hasher := sha256.New()
+	n, err := tx.WriteTo(hasher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(tx.UnsignedBytes())) {
+		t.Fatalf("expected %v bytes written got %v", len(tx.UnsignedBytes()), n)
+	}
+	hash := sha256.Sum256(hasher.Sum(nil))
+	if hex.EncodeToString(reverseBytes(hash[:])) != tx.ToTXID() {
+		t.Fatalf("expected %v got %x", tx.ToTXID(), reverseBytes(hash[:]))
+	}
+
+	//with the witnesses it writes the same bytes as ToBytes
+	tx.Script = []byte{0x01, 0x00, 0x00}
+	buffer := bytes.Buffer{}
+	n, err = tx.WriteTo(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buffer.Len()) || hex.EncodeToString(buffer.Bytes()) != hex.EncodeToString(tx.ToBytes()) {
+		t.Fatalf("expected %x got %x", tx.ToBytes(), buffer.Bytes())
+	}
+}
+
+func TestPushLength(t *testing.T) {
+	s := &ScriptBuilder{}
+	if err := s.pushLength(-1); err == nil {
+		t.Fatal("expected an error for a negative length")
+	}
+	if err := s.pushLength(maxVarIntCount + 1); err == nil {
+		t.Fatal("expected an error for a length over the maximum")
+	}
+	if len(s.RawBytes) != 0 {
+		t.Fatalf("nothing should be pushed got %x", s.RawBytes)
+	}
+
+	//var int prefix. 0xfd and over take more than one byte
+	for count, expected := range map[int]string{0: "00", 0xfc: "fc", 0xfd: "fdfd00", 256: "fd0001", 0x10000: "fe00000100"} {
+		s := &ScriptBuilder{}
+		err := s.pushLength(count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(s.RawBytes) != expected {
+			t.Fatalf("expected %v for %v got %x", expected, count, s.RawBytes)
+		}
+	}
+}
+
+func TestInvocationTransactionGas(t *testing.T) {
+	script := []byte{byte(PUSH1), byte(RET)}
+	data, err := NewScriptBuilder().GenerateInvocationTransactionData(script, NewFixed8(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//[length] + [script] + [10 GAS = 1000000000 in little endian]
+	if hex.EncodeToString(data) != "025166"+"00ca9a3b00000000" {
+		t.Fatalf("unexpected exclusive data %x", data)
+	}
+
+	tx := NewInvocationTransactionWithGas()
+	tx.Data = data
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	parsed, err := DeserializeTransaction(tx.ToBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gas, err := parsed.InvocationGas()
+	if err != nil || gas != NewFixed8(10) {
+		t.Fatalf("expected 10 GAS got %v %v", gas, err)
+	}
+	if bytes.Equal(parsed.Data, data) == false {
+		t.Fatalf("expected data %x got %x", data, parsed.Data)
+	}
+
+	//version 0 has no gas field
+	v0 := NewInvocationTransaction()
+	v0.Data = NewScriptBuilder().GenerateContractInvocationData(ScriptHash(make([]byte, 20)), "name", nil)
+	gas, err = v0.InvocationGas()
+	if err != nil || gas != 0 {
+		t.Fatalf("expected no gas got %v %v", gas, err)
+	}
+
+	_, err = NewScriptBuilder().GenerateInvocationTransactionData(script, NewFixed8(0.5))
+	if err == nil {
+		t.Fatal("expected an error for a fraction of GAS")
+	}
+	_, err = NewScriptBuilder().GenerateInvocationTransactionData(nil, NewFixed8(1))
+	if err == nil {
+		t.Fatal("expected an error for an empty script")
+	}
+}
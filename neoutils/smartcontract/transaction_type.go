@@ -1,5 +1,7 @@
 package smartcontract
 
+import "fmt"
+
 type TransactionType byte
 
 const (
@@ -8,8 +10,30 @@ const (
 	ClaimTransaction      TransactionType = 0x02
 	EnrollmentTransaction TransactionType = 0x20
 	RegisterTransaction   TransactionType = 0x40
+	AgencyTransaction     TransactionType = 0xb0 //AntShares only
 	ContractTransaction   TransactionType = 0x80
 	StateTransaction      TransactionType = 0x90
 	PublishTransaction    TransactionType = 0xd0
 	InvocationTransaction TransactionType = 0xd1
 )
+
+var transactionTypeNames = map[TransactionType]string{
+	MinerTransaction:      "MinerTransaction",
+	IssueTransaction:      "IssueTransaction",
+	ClaimTransaction:      "ClaimTransaction",
+	EnrollmentTransaction: "EnrollmentTransaction",
+	RegisterTransaction:   "RegisterTransaction",
+	AgencyTransaction:     "AgencyTransaction",
+	ContractTransaction:   "ContractTransaction",
+	StateTransaction:      "StateTransaction",
+	PublishTransaction:    "PublishTransaction",
+	InvocationTransaction: "InvocationTransaction",
+}
+
+func (t TransactionType) String() string {
+	name, ok := transactionTypeNames[t]
+	if !ok {
+		return fmt.Sprintf("0x%02x", byte(t))
+	}
+	return name
+}
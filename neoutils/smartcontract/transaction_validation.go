@@ -0,0 +1,104 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+//sum of the values of the inputs section per asset. the values are looked up in unspent
+func sumInputs(unspent Unspent, inputs []byte) (map[NativeAsset]Fixed8, error) {
+	r := newBinaryReader(inputs)
+	items := readFixedLengthItems(r, transactionInputLength)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	sum := map[NativeAsset]Fixed8{}
+	for _, item := range items {
+		txID := hex.EncodeToString(reverseBytes(item[:32]))
+		index := int(binary.LittleEndian.Uint16(item[32:]))
+		found := false
+		for asset, balance := range unspent.Assets {
+			if balance == nil {
+				continue
+			}
+			for _, utxo := range balance.UTXOs {
+				if utxo.Index != index || strings.TrimPrefix(strings.ToLower(utxoBigEndianTXID(utxo)), "0x") != txID {
+					continue
+				}
+				sum[asset] += NewFixed8(utxo.Value)
+				found = true
+				break
+			}
+			if found == true {
+				break
+			}
+		}
+		if found == false {
+			return nil, fmt.Errorf("input %v:%v not found in UTXO", txID, index)
+		}
+	}
+	return sum, nil
+}
+
+//big endian txID of the UTXO regardless of TXIDByteOrder
+func utxoBigEndianTXID(utxo UTXO) string {
+	if utxo.TXIDByteOrder != binary.LittleEndian {
+		return utxo.TXID
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(utxo.TXID, "0x"))
+	if err != nil {
+		return utxo.TXID
+	}
+	return hex.EncodeToString(reverseBytes(b))
+}
+
+//sum of the outputs section per asset
+func sumOutputs(outputs []byte) (map[NativeAsset]Fixed8, error) {
+	r := newBinaryReader(outputs)
+	items := readFixedLengthItems(r, transactionOutputLength)
+	if r.err != nil {
+		return nil, r.err
+	}
+	sum := map[NativeAsset]Fixed8{}
+	for _, item := range items {
+		asset := NativeAsset(hex.EncodeToString(reverseBytes(item[:32])))
+		sum[asset] += Fixed8(binary.LittleEndian.Uint64(item[32:40]))
+	}
+	return sum, nil
+}
+
+//ValidateInputsCoverOutputs makes sure the inputs pay for every output plus the network fee
+//so a transaction with underfunded outputs or negative change is never built.
+//inputs and outputs are the sections generated by GenerateTransactionInput and GenerateTransactionOutput
+//sum(inputs) >= sum(outputs) for every asset and sum(GAS inputs) >= sum(GAS outputs) + fee
+func ValidateInputsCoverOutputs(unspent Unspent, inputs []byte, outputs []byte, networkFeeAmount NetworkFeeAmount) error {
+	inputSum, err := sumInputs(unspent, inputs)
+	if err != nil {
+		return err
+	}
+	outputSum, err := sumOutputs(outputs)
+	if err != nil {
+		return err
+	}
+
+	fee := NewFixed8(float64(networkFeeAmount))
+	if _, ok := outputSum[GAS]; !ok && fee > 0 {
+		outputSum[GAS] = 0
+	}
+	for asset, out := range outputSum {
+		required := out
+		if asset == GAS {
+			required += fee
+		}
+		if inputSum[asset] < required {
+			if asset == GAS && fee > 0 {
+				return fmt.Errorf("inputs of asset %v total %v but %v is required (outputs %v + network fee %v)", asset, inputSum[asset], required, out, fee)
+			}
+			return fmt.Errorf("inputs of asset %v total %v but outputs total %v", asset, inputSum[asset], out)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package smartcontract_test
+
+import (
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestValidateInputsCoverOutputsWithoutFee(t *testing.T) {
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "0x1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1},
+				},
+			},
+		},
+	}
+	sender := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	receiver := smartcontract.ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+
+	//inputs and outputs are generated without the fee so they only cover the amount
+	inputs, err := smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, smartcontract.GAS, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs, err := smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, smartcontract.GAS, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = smartcontract.ValidateInputsCoverOutputs(unspent, inputs, outputs, 0.5)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := "inputs of asset GAS total 1 but 1.5 is required (outputs 1 + network fee 0.5)"
+	if err.Error() != expected {
+		t.Fatalf("expected %v got %v", expected, err)
+	}
+
+	err = smartcontract.ValidateInputsCoverOutputs(unspent, inputs, outputs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
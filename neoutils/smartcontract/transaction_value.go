@@ -0,0 +1,64 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+//OutputResolver returns the output index of txID that an input spends.
+//txID is big endian the way explorers show it. e.g. look it up with getrawtransaction or in the UTXOs of the sender
+type OutputResolver func(txID string, index int) (TransactionOutput, error)
+
+//OutputSums returns the total value of the outputs of the transaction for each asset
+func (t *Transaction) OutputSums() (map[NativeAsset]Fixed8, error) {
+	return sumOutputs(t.Outputs)
+}
+
+//InputSums returns the total value of the outputs the inputs of the transaction spend for each asset
+func (t *Transaction) InputSums(resolver OutputResolver) (map[NativeAsset]Fixed8, error) {
+	items, err := readFixedLengthSection(t.Inputs, transactionInputLength)
+	if err != nil {
+		return nil, err
+	}
+	sum := map[NativeAsset]Fixed8{}
+	for _, item := range items {
+		txID := hex.EncodeToString(reverseBytes(append([]byte{}, item[:32]...)))
+		index := int(binary.LittleEndian.Uint16(item[32:]))
+		output, err := resolver(txID, index)
+		if err != nil {
+			return nil, err
+		}
+		sum[output.Asset] += output.Value
+	}
+	return sum, nil
+}
+
+//ImplicitFee returns the GAS the inputs spend that doesn't go to any output which is the network and system fee paid.
+//only GAS can be left out of the outputs so an other asset with more inputs than outputs is an error.
+//claim, issue and miner transactions are an error too. their outputs create GAS or an asset that no input spends
+func (t *Transaction) ImplicitFee(resolver OutputResolver) (Fixed8, error) {
+	switch t.Type {
+	case ClaimTransaction, IssueTransaction, MinerTransaction:
+		return 0, fmt.Errorf("outputs of a %v are more than its inputs by design", t.Type)
+	}
+	inputSum, err := t.InputSums(resolver)
+	if err != nil {
+		return 0, err
+	}
+	outputSum, err := t.OutputSums()
+	if err != nil {
+		return 0, err
+	}
+	for asset, out := range outputSum {
+		if inputSum[asset] < out {
+			return 0, fmt.Errorf("outputs of asset %v total %v but inputs only %v", asset, out, inputSum[asset])
+		}
+	}
+	for asset, in := range inputSum {
+		if asset != GAS && in != outputSum[asset] {
+			return 0, fmt.Errorf("inputs of asset %v total %v but outputs %v. only GAS can be paid as a fee", asset, in, outputSum[asset])
+		}
+	}
+	return inputSum[GAS] - outputSum[GAS], nil
+}
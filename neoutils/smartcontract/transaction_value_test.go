@@ -0,0 +1,78 @@
+package smartcontract_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestTransactionImplicitFee(t *testing.T) {
+	utxos := map[string]smartcontract.TransactionOutput{
+		"1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe:0": {Asset: smartcontract.GAS, Value: smartcontract.NewFixed8(10)},
+		"ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0:1": {Asset: smartcontract.NEO, Value: smartcontract.NewFixed8(5)},
+	}
+	resolver := func(txID string, index int) (smartcontract.TransactionOutput, error) {
+		output, ok := utxos[fmt.Sprintf("%v:%v", txID, index)]
+		if ok == false {
+			return output, fmt.Errorf("unknown output %v:%v", txID, index)
+		}
+		return output, nil
+	}
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{UTXOs: []smartcontract.UTXO{{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 10}}},
+			smartcontract.NEO: &smartcontract.Balance{UTXOs: []smartcontract.UTXO{{Index: 1, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 5}}},
+		},
+	}
+	sender := smartcontract.ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	receiver := smartcontract.ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+
+	//2 NEO with a network fee of 0.5 GAS
+	tx := smartcontract.NewContractTransaction()
+	var err error
+	tx.Inputs, err = smartcontract.NewScriptBuilder().GenerateTransactionInput(unspent, smartcontract.NEO, 2, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Outputs, err = smartcontract.NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, smartcontract.NEO, 2, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs, err := tx.InputSums(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs, err := tx.OutputSums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inputs[smartcontract.NEO] != smartcontract.NewFixed8(5) || inputs[smartcontract.GAS] != smartcontract.NewFixed8(10) {
+		t.Fatalf("unexpected input sums %v", inputs)
+	}
+	if outputs[smartcontract.NEO] != smartcontract.NewFixed8(5) || outputs[smartcontract.GAS] != smartcontract.NewFixed8(9.5) {
+		t.Fatalf("unexpected output sums %v", outputs)
+	}
+	fee, err := tx.ImplicitFee(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != smartcontract.NewFixed8(0.5) {
+		t.Fatalf("expected a fee of 0.5 got %v", fee)
+	}
+
+	//an input that can't be resolved
+	delete(utxos, "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe:0")
+	_, err = tx.ImplicitFee(resolver)
+	if err == nil {
+		t.Fatal("expected an error for an unknown input")
+	}
+
+	//a claim transaction outputs more GAS than its inputs spend
+	claim := smartcontract.NewClaimTransaction()
+	_, err = claim.ImplicitFee(resolver)
+	if err == nil {
+		t.Fatal("expected an error for a claim transaction")
+	}
+}
@@ -0,0 +1,169 @@
+package smartcontract
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/o3labs/neo-utils/neoutils/btckey"
+)
+
+//reads the signatures pushed by an invocation script. each one is PUSHBYTES64 [signature]
+func readInvocationSignatures(script []byte) ([][]byte, error) {
+	signatures := [][]byte{}
+	offset := 0
+	for offset < len(script) {
+		op := script[offset]
+		if op < byte(PUSHBYTES1) || op > byte(PUSHBYTES75) {
+			return nil, fmt.Errorf("unexpected opcode 0x%02x in invocation script at %v", op, offset)
+		}
+		length := int(op)
+		if offset+1+length > len(script) {
+			return nil, fmt.Errorf("unexpected end of invocation script")
+		}
+		signatures = append(signatures, script[offset+1:offset+1+length])
+		offset += 1 + length
+	}
+	return signatures, nil
+}
+
+func verifySignature(publicKey []byte, signature []byte, hash []byte) bool {
+	//btckey.Verify expects r+s
+	if len(signature) != 64 {
+		return false
+	}
+	return btckey.Verify(publicKey, signature, hash)
+}
+
+//a high s verifies on chain but it's a malleated copy of the low s signature which changes the witness without the signer
+func verifyCanonicalSignature(publicKey []byte, signature []byte, hash []byte) bool {
+	if btckey.IsCanonicalSignature(signature) == false {
+		return false
+	}
+	return btckey.Verify(publicKey, signature, hash)
+}
+
+//VerifyWitness checks a standard verification script against the signatures of its invocation script
+//the same way CHECKSIG and CHECKMULTISIG do in the VM.
+//for CHECKMULTISIG the signatures must be in the same order as the public keys and each public key is used once
+func VerifyWitness(witness TransactionValidationScript, signingHash []byte) error {
+	return verifyWitness(witness, signingHash, verifyCanonicalSignature)
+}
+
+func verifyWitness(witness TransactionValidationScript, signingHash []byte, verify func(publicKey []byte, signature []byte, hash []byte) bool) error {
+	kind, m, _, publicKeys, err := ClassifyVerificationScript(witness.VerificationScript())
+	if err != nil {
+		return err
+	}
+	if kind == VerificationScriptUnknown {
+		return fmt.Errorf("non-standard verification script")
+	}
+	signatures, err := readInvocationSignatures(witness.InvocationScript())
+	if err != nil {
+		return err
+	}
+	//any extra signature is left on the stack and the node rejects a witness that doesn't end with exactly one item
+	if len(signatures) != m {
+		return fmt.Errorf("%v verification script needs %v signatures but %v are given", kind, m, len(signatures))
+	}
+
+	keyIndex := 0
+	for _, signature := range signatures {
+		for keyIndex < len(publicKeys) && verify(publicKeys[keyIndex], signature, signingHash) == false {
+			keyIndex++
+		}
+		if keyIndex == len(publicKeys) {
+			return fmt.Errorf("signature %x doesn't match any remaining public key", signature)
+		}
+		keyIndex++
+	}
+	return nil
+}
+
+//VerifyTransactionSignature verifies every witness of a signed transaction against its signing hash without a VM.
+//only the standard single signature and multi signature verification scripts are supported.
+//it doesn't check that the witnesses belong to the owners of the inputs.
+//a signature must be 64 bytes r+s with a low s (btckey.IsCanonicalSignature) so a malleated copy of a signature is rejected
+func VerifyTransactionSignature(rawTransaction []byte) error {
+	return verifyTransactionSignature(rawTransaction, verifyCanonicalSignature)
+}
+
+//VerifyTransactionSignatureLenient is VerifyTransactionSignature that accepts a signature with a high s.
+//NEO 2 consensus accepts it so use it to check a transaction that is already on chain
+func VerifyTransactionSignatureLenient(rawTransaction []byte) error {
+	return verifyTransactionSignature(rawTransaction, verifySignature)
+}
+
+func verifyTransactionSignature(rawTransaction []byte, verify func(publicKey []byte, signature []byte, hash []byte) bool) error {
+	tx, err := DeserializeTransaction(rawTransaction)
+	if err != nil {
+		return err
+	}
+	witnesses, err := tx.Witnesses()
+	if err != nil {
+		return err
+	}
+	if len(witnesses) == 0 {
+		return fmt.Errorf("transaction is not signed")
+	}
+	hash := tx.SigningHash()
+	for i, witness := range witnesses {
+		err := verifyWitness(witness, hash, verify)
+		if err != nil {
+			return fmt.Errorf("witness %v: %v", i, err)
+		}
+	}
+	return nil
+}
+
+//SignedTx is a raw signed transaction
+type SignedTx []byte
+
+//public keys decoded once for a batch. decompressing a public key costs about as much as verifying a signature
+//and a service usually sees the same few keys again and again
+type publicKeyCache struct {
+	keys map[string]*ecdsa.PublicKey
+	//accepts a high s like VerifyTransactionSignatureLenient
+	lenient bool
+}
+
+func (c publicKeyCache) verifySignature(publicKey []byte, signature []byte, hash []byte) bool {
+	if len(signature) != 64 || (c.lenient == false && btckey.IsCanonicalSignature(signature) == false) {
+		return false
+	}
+	key, ok := c.keys[string(publicKey)]
+	if ok == false {
+		pub := btckey.PublicKey{}
+		if pub.FromBytes(publicKey) == nil && pub.X != nil && pub.Y != nil {
+			key = &ecdsa.PublicKey{Curve: elliptic.P256(), X: pub.X, Y: pub.Y}
+		}
+		//an invalid key is cached as nil so it isn't decoded again
+		c.keys[string(publicKey)] = key
+	}
+	if key == nil {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[0:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	return ecdsa.Verify(key, hash, r, s)
+}
+
+//VerifyBatch is VerifyTransactionSignature for many transactions. it returns one error for each transaction in the same order
+//and nil for the ones that are valid. public keys are decoded once for the whole batch
+func VerifyBatch(transactions []SignedTx) []error {
+	return verifyBatch(transactions, publicKeyCache{keys: map[string]*ecdsa.PublicKey{}})
+}
+
+//VerifyBatchLenient is VerifyBatch that accepts a signature with a high s like VerifyTransactionSignatureLenient
+func VerifyBatchLenient(transactions []SignedTx) []error {
+	return verifyBatch(transactions, publicKeyCache{keys: map[string]*ecdsa.PublicKey{}, lenient: true})
+}
+
+func verifyBatch(transactions []SignedTx, cache publicKeyCache) []error {
+	errs := make([]error, len(transactions))
+	for i, tx := range transactions {
+		errs[i] = verifyTransactionSignature(tx, cache.verifySignature)
+	}
+	return errs
+}
@@ -0,0 +1,222 @@
+package smartcontract_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/btckey"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+type verificationKey struct {
+	privateKey string
+	publicKey  []byte
+}
+
+func newVerificationKeys(t testing.TB, count int) []verificationKey {
+	keys := []verificationKey{}
+	for i := 0; i < count; i++ {
+		priv, err := btckey.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, verificationKey{privateKey: hex.EncodeToString(priv.ToBytes()), publicKey: priv.PublicKey.ToBytes()})
+	}
+	return keys
+}
+
+func unsignedVerificationTransaction() smartcontract.Transaction {
+	tx := smartcontract.NewContractTransaction()
+	tx.Attributes = []byte{0x00}
+	tx.Inputs = []byte{0x00}
+	tx.Outputs = []byte{0x00}
+	return tx
+}
+
+//signs the transaction with each key and pushes the signatures in the same order
+func invocationScript(t testing.TB, tx smartcontract.Transaction, keys []verificationKey) []byte {
+	script := []byte{}
+	for _, key := range keys {
+		signature, err := btckey.Sign(tx.UnsignedBytes(), key.privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		script = append(script, byte(len(signature)))
+		script = append(script, signature...)
+	}
+	return script
+}
+
+func withWitness(tx smartcontract.Transaction, invocation []byte, verification []byte) []byte {
+	script := smartcontract.TransactionValidationScript{StackScript: invocation, RedeemScript: verification}
+	tx.Script = smartcontract.NewScriptBuilder().GenerateVerificationScripts([]interface{}{script})
+	return tx.ToBytes()
+}
+
+func TestVerifyTransactionSignatureSingleSig(t *testing.T) {
+	keys := newVerificationKeys(t, 2)
+	tx := unsignedVerificationTransaction()
+	verification := append(append([]byte{0x21}, keys[0].publicKey...), byte(smartcontract.CHECKSIG))
+
+	err := smartcontract.VerifyTransactionSignature(withWitness(tx, invocationScript(t, tx, keys[:1]), verification))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//signed by another key
+	err = smartcontract.VerifyTransactionSignature(withWitness(tx, invocationScript(t, tx, keys[1:]), verification))
+	if err == nil {
+		t.Fatal("expected an error for a signature of another key")
+	}
+
+	//no signature
+	err = smartcontract.VerifyTransactionSignature(withWitness(tx, []byte{}, verification))
+	if err == nil {
+		t.Fatal("expected an error without a signature")
+	}
+
+	//a valid signature with an extra one pushed before it
+	err = smartcontract.VerifyTransactionSignature(withWitness(tx, invocationScript(t, tx, []verificationKey{keys[1], keys[0]}), verification))
+	if err == nil {
+		t.Fatal("expected an error for more signatures than the script needs")
+	}
+}
+
+func TestVerifyTransactionSignatureMultiSig(t *testing.T) {
+	keys := newVerificationKeys(t, 3)
+	tx := unsignedVerificationTransaction()
+
+	//2 of 3
+	verification := []byte{byte(smartcontract.PUSH2)}
+	for _, key := range keys {
+		verification = append(verification, 0x21)
+		verification = append(verification, key.publicKey...)
+	}
+	verification = append(verification, byte(smartcontract.PUSH3), byte(smartcontract.CHECKMULTISIG))
+
+	valid := [][]verificationKey{
+		{keys[0], keys[1]},
+		{keys[0], keys[2]},
+		{keys[1], keys[2]},
+	}
+	for _, signers := range valid {
+		err := smartcontract.VerifyTransactionSignature(withWitness(tx, invocationScript(t, tx, signers), verification))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	invalid := [][]verificationKey{
+		//insufficient signatures
+		{keys[0]},
+		//not in the order of the public keys
+		{keys[2], keys[0]},
+		//the same key twice
+		{keys[1], keys[1]},
+		//one more than required
+		{keys[0], keys[1], keys[2]},
+	}
+	for _, signers := range invalid {
+		err := smartcontract.VerifyTransactionSignature(withWitness(tx, invocationScript(t, tx, signers), verification))
+		if err == nil {
+			t.Fatalf("expected an error for %v signatures", len(signers))
+		}
+	}
+}
+
+func TestVerifyTransactionSignatureHighS(t *testing.T) {
+	keys := newVerificationKeys(t, 1)
+	tx := unsignedVerificationTransaction()
+	verification := append(append([]byte{0x21}, keys[0].publicKey...), byte(smartcontract.CHECKSIG))
+	invocation := invocationScript(t, tx, keys)
+	signature := invocation[1:]
+	if btckey.IsCanonicalSignature(signature) == false {
+		t.Fatalf("expected a low s signature got %x", signature)
+	}
+
+	//N-s verifies the same as s but it's a malleated signature
+	n := elliptic.P256().Params().N
+	s := new(big.Int).Sub(n, new(big.Int).SetBytes(signature[32:]))
+	malleated := make([]byte, 64)
+	copy(malleated, signature[:32])
+	s.FillBytes(malleated[32:])
+	hash := tx.SigningHash()
+	if btckey.Verify(keys[0].publicKey, malleated, hash) == false {
+		t.Fatal("expected the high s signature to be a valid ecdsa signature")
+	}
+	highS := withWitness(tx, append([]byte{0x40}, malleated...), verification)
+	err := smartcontract.VerifyTransactionSignature(highS)
+	if err == nil {
+		t.Fatal("expected an error for a high s signature")
+	}
+	errs := smartcontract.VerifyBatch([]smartcontract.SignedTx{highS})
+	if errs[0] == nil {
+		t.Fatal("expected the batch to reject a high s signature")
+	}
+	//NEO 2 consensus accepts a high s so the lenient check does too
+	err = smartcontract.VerifyTransactionSignatureLenient(highS)
+	lenientErrs := smartcontract.VerifyBatchLenient([]smartcontract.SignedTx{highS})
+	if err != nil || lenientErrs[0] != nil {
+		t.Fatalf("expected the lenient checks to accept a high s got %v %v", err, lenientErrs[0])
+	}
+	err = smartcontract.VerifyTransactionSignature(withWitness(tx, invocation, verification))
+	if err != nil {
+		t.Fatalf("expected the low s signature to be valid got %v", err)
+	}
+
+	//r+s with one byte too many
+	longSignature := withWitness(tx, append([]byte{0x41}, append(signature, 0x00)...), verification)
+	if smartcontract.VerifyTransactionSignatureLenient(longSignature) == nil || smartcontract.VerifyBatchLenient([]smartcontract.SignedTx{longSignature})[0] == nil {
+		t.Fatal("expected an error for a 65 bytes signature")
+	}
+}
+
+func TestVerifyBatch(t *testing.T) {
+	keys := newVerificationKeys(t, 2)
+	tx := unsignedVerificationTransaction()
+	verification := append(append([]byte{0x21}, keys[0].publicKey...), byte(smartcontract.CHECKSIG))
+	other := unsignedVerificationTransaction()
+	other.Version = smartcontract.TradingVersion(1)
+	otherVerification := append(append([]byte{0x21}, keys[1].publicKey...), byte(smartcontract.CHECKSIG))
+
+	batch := []smartcontract.SignedTx{
+		withWitness(tx, invocationScript(t, tx, keys[:1]), verification),
+		withWitness(tx, invocationScript(t, tx, keys[1:]), verification), //signed by another key
+		withWitness(other, invocationScript(t, other, keys[1:]), otherVerification),
+		withWitness(other, invocationScript(t, tx, keys[1:]), otherVerification), //signature of another transaction
+		smartcontract.SignedTx{0x80, 0x00},
+		withWitness(tx, invocationScript(t, tx, keys[:1]), verification),
+	}
+	errs := smartcontract.VerifyBatch(batch)
+	if len(errs) != len(batch) {
+		t.Fatalf("expected %v results got %v", len(batch), len(errs))
+	}
+	valid := []bool{true, false, true, false, false, true}
+	for i := range batch {
+		if (errs[i] == nil) != valid[i] {
+			t.Errorf("transaction %v: expected valid %v got %v", i, valid[i], errs[i])
+		}
+		//the same result as verifying on its own
+		if (smartcontract.VerifyTransactionSignature(batch[i]) == nil) != valid[i] {
+			t.Errorf("transaction %v: VerifyTransactionSignature doesn't match", i)
+		}
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	keys := newVerificationKeys(b, 1)
+	tx := unsignedVerificationTransaction()
+	verification := append(append([]byte{0x21}, keys[0].publicKey...), byte(smartcontract.CHECKSIG))
+	raw := withWitness(tx, invocationScript(b, tx, keys), verification)
+	batch := []smartcontract.SignedTx{}
+	for i := 0; i < 100; i++ {
+		batch = append(batch, raw)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		smartcontract.VerifyBatch(batch)
+	}
+}
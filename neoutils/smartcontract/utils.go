@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"math"
+	"math/big"
 )
 
 func reverseBytes(b []byte) []byte {
@@ -20,12 +21,80 @@ func uintToBytes(value uint) []byte {
 	return bytes.TrimRight(countBytes, "\x00")
 }
 
+//variable length integer used in NEO network protocol to prefix the length of data
+//http://docs.neo.org/en-us/network/network-protocol.html
+func varIntBytes(value uint64) []byte {
+	switch {
+	case value < 0xfd:
+		return []byte{byte(value)}
+	case value <= 0xffff:
+		b := make([]byte, 3)
+		b[0] = 0xfd
+		binary.LittleEndian.PutUint16(b[1:], uint16(value))
+		return b
+	case value <= 0xffffffff:
+		b := make([]byte, 5)
+		b[0] = 0xfe
+		binary.LittleEndian.PutUint32(b[1:], uint32(value))
+		return b
+	}
+	b := make([]byte, 9)
+	b[0] = 0xff
+	binary.LittleEndian.PutUint64(b[1:], value)
+	return b
+}
+
+//integer in the format the VM reads it. little endian two's complement with the minimum number of bytes
+//same as BigInteger.ToByteArray() in C#. e.g. 17 = 0x11, 128 = 0x8000, -2 = 0xfe
+func intToBytes(value int64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(value))
+	return trimSignExtension(b)
+}
+
+//removes the sign extension bytes of a little endian two's complement integer as long as the sign bit stays the same
+func trimSignExtension(b []byte) []byte {
+	for len(b) > 1 {
+		last := b[len(b)-1]
+		signBit := b[len(b)-2] & 0x80
+		if (last == 0x00 && signBit == 0) || (last == 0xff && signBit != 0) {
+			b = b[:len(b)-1]
+			continue
+		}
+		break
+	}
+	return b
+}
+
+//same as intToBytes for an integer of any size
+func bigIntToBytes(value *big.Int) []byte {
+	if value.Sign() == 0 {
+		return []byte{}
+	}
+	//two's complement in big endian with one more byte for the sign then trimmed like intToBytes
+	length := len(value.Bytes()) + 1
+	v := new(big.Int).Set(value)
+	if v.Sign() < 0 {
+		v.Add(v, new(big.Int).Lsh(big.NewInt(1), uint(length*8)))
+	}
+	b := make([]byte, length)
+	bigEndian := v.Bytes()
+	copy(b[length-len(bigEndian):], bigEndian)
+	return trimSignExtension(reverseBytes(b))
+}
+
 func uint16ToFixBytes(value uint16) []byte {
 	countBytes := make([]byte, 2)
 	binary.LittleEndian.PutUint16(countBytes, value)
 	return countBytes
 }
 
+func uint32ToFixBytes(value uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, value)
+	return b
+}
+
 func RoundFixed8(val float64) (newVal float64) {
 	var round float64
 	pow := math.Pow(10, float64(8))
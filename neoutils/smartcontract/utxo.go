@@ -1,6 +1,7 @@
 package smartcontract
 
 import (
+	"encoding/binary"
 	"sort"
 )
 
@@ -8,6 +9,11 @@ type UTXO struct {
 	Index int
 	TXID  string
 	Value float64
+	//byte order of TXID. nil means big endian which is the form shown on explorers and returned by most APIs
+	//set it to binary.LittleEndian when TXID is already in the order it's serialized in a transaction input
+	TXIDByteOrder binary.ByteOrder
+	//number of blocks confirming the UTXO. 0 means it is not in a block yet
+	Confirmations int
 }
 
 type Balance struct {
@@ -1,6 +1,8 @@
 package smartcontract
 
 import (
+	"encoding/binary"
+	"fmt"
 	"log"
 	"testing"
 )
@@ -67,3 +69,81 @@ func TestSortUTXOMinFirst(t *testing.T) {
 	gasBalance.SortMinFirst()
 	log.Printf("after sort %+v", gasBalance)
 }
+
+func TestUTXOTXIDByteOrder(t *testing.T) {
+	bigEndian := UTXO{
+		Index: 1,
+		TXID:  "0xad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0",
+	}
+	littleEndian := UTXO{
+		Index:         1,
+		TXID:          "c0848942be7b95beeda620ed484c26c763459a987a5836ea3d87e12dc2658dad",
+		TXIDByteOrder: binary.LittleEndian,
+	}
+
+	sb1 := NewScriptBuilder()
+	sb1.Push(bigEndian)
+	sb2 := NewScriptBuilder()
+	sb2.Push(littleEndian)
+
+	expected := "c0848942be7b95beeda620ed484c26c763459a987a5836ea3d87e12dc2658dad0100"
+	if sb1.FullHexString() != expected || sb2.FullHexString() != expected {
+		t.Fatalf("expected %v got %v and %v", expected, sb1.FullHexString(), sb2.FullHexString())
+	}
+}
+
+func TestValidateInputsTXIDByteOrder(t *testing.T) {
+	sender := ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	receiver := ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+	utxos := []UTXO{
+		{Index: 1, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 2},
+		{Index: 1, TXID: "c0848942be7b95beeda620ed484c26c763459a987a5836ea3d87e12dc2658dad", Value: 2, TXIDByteOrder: binary.LittleEndian},
+	}
+	expected := "01c0848942be7b95beeda620ed484c26c763459a987a5836ea3d87e12dc2658dad0100"
+	for _, utxo := range utxos {
+		unspent := Unspent{Assets: map[NativeAsset]*Balance{NEO: &Balance{UTXOs: []UTXO{utxo}}}}
+		inputs, err := NewScriptBuilder().GenerateTransactionInput(unspent, NEO, 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprintf("%x", inputs) != expected {
+			t.Fatalf("expected %v got %x", expected, inputs)
+		}
+		outputs, err := NewScriptBuilder().GenerateTransactionOutput(sender, receiver, unspent, NEO, 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//the input is found in unspent whichever byte order the UTXO has
+		err = ValidateInputsCoverOutputs(unspent, inputs, outputs, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNegativeChangeOutput(t *testing.T) {
+	sender := ParseNEOAddress("AQV8FNNi2o7EtMNn4etWBYx1cqBREAifgE")
+	receiver := ParseNEOAddress("AKo8k27H5nCG8MwSirmnraH6uUG6fQQVC2")
+	//inputs of 1 GAS can't pay 1 GAS and a 0.1 network fee
+	total := NewFixed8(1)
+	change := total.Sub(NewFixed8(1).Add(NewFixed8(0.1)))
+	list := []TransactionOutput{
+		{Asset: GAS, Value: NewFixed8(1), Address: receiver},
+		{Asset: GAS, Value: change, Address: sender},
+	}
+	s := &ScriptBuilder{}
+	err := s.pushOutputs(list)
+	if err == nil {
+		t.Fatal("expected an error for a negative change output")
+	}
+	if len(s.ToBytes()) != 0 {
+		t.Fatalf("nothing should be pushed got %x", s.ToBytes())
+	}
+
+	//negative amount to send
+	utxos := []UTXO{{Index: 0, TXID: "ad8d65c22de1873dea36587a989a4563c7264c48ed20a6edbe957bbe428984c0", Value: 1}}
+	_, err = NewScriptBuilder().GenerateTransactionOutputFromUTXOs(sender, receiver, utxos, GAS, -1, 0)
+	if err == nil {
+		t.Fatal("expected an error for a negative output")
+	}
+}
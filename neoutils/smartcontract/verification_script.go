@@ -0,0 +1,103 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	VerificationScriptSingleSig = "singlesig"
+	VerificationScriptMultiSig  = "multisig"
+	VerificationScriptUnknown   = "unknown"
+)
+
+//compressed public key length
+const publicKeyLength = 33
+
+//reads an integer pushed by PUSH1-PUSH16 or by a short byte array for a value over 16
+//returns the value and the offset after the push
+func readPushedInt(script []byte, offset int) (int, int, error) {
+	if offset >= len(script) {
+		return 0, offset, fmt.Errorf("unexpected end of script")
+	}
+	op := script[offset]
+	if op >= byte(PUSH1) && op <= byte(PUSH16) {
+		return int(op) - int(PUSH1) + 1, offset + 1, nil
+	}
+	if op >= byte(PUSHBYTES1) && op <= 0x04 {
+		length := int(op)
+		if offset+1+length > len(script) {
+			return 0, offset, fmt.Errorf("unexpected end of script")
+		}
+		b := make([]byte, 8)
+		copy(b, script[offset+1:offset+1+length])
+		return int(binary.LittleEndian.Uint64(b)), offset + 1 + length, nil
+	}
+	return 0, offset, fmt.Errorf("expected an integer at %v but found opcode 0x%02x", offset, op)
+}
+
+//true when the PUSHBYTES at the offset needs more bytes than the script has left
+func isTruncatedPush(script []byte, offset int) bool {
+	if offset >= len(script) {
+		return false
+	}
+	op := script[offset]
+	return op >= byte(PUSHBYTES1) && op <= byte(PUSHBYTES75) && offset+1+int(op) > len(script)
+}
+
+func isPublicKeyPush(script []byte, offset int) bool {
+	if offset+1+publicKeyLength > len(script) {
+		return false
+	}
+	if script[offset] != publicKeyLength {
+		return false
+	}
+	prefix := script[offset+1]
+	return prefix == 0x02 || prefix == 0x03
+}
+
+//ClassifyVerificationScript recognizes the standard verification scripts
+//single signature: PUSHBYTES33 [public key] CHECKSIG
+//multi signature: PUSH m + n x (PUSHBYTES33 [public key]) + PUSH n + CHECKMULTISIG
+//anything else is classified as unknown without an error. the error is for a malformed script
+func ClassifyVerificationScript(script []byte) (kind string, m int, n int, pubkeys [][]byte, err error) {
+	if len(script) == 0 {
+		return VerificationScriptUnknown, 0, 0, nil, fmt.Errorf("empty verification script")
+	}
+	if isTruncatedPush(script, 0) {
+		return VerificationScriptUnknown, 0, 0, nil, fmt.Errorf("malformed verification script: unexpected end of script")
+	}
+
+	//single signature
+	if len(script) == 1+publicKeyLength+1 && isPublicKeyPush(script, 0) && script[len(script)-1] == byte(CHECKSIG) {
+		return VerificationScriptSingleSig, 1, 1, [][]byte{script[1 : 1+publicKeyLength]}, nil
+	}
+
+	if script[len(script)-1] != byte(CHECKMULTISIG) {
+		return VerificationScriptUnknown, 0, 0, nil, nil
+	}
+
+	m, offset, err := readPushedInt(script, 0)
+	if err != nil {
+		return VerificationScriptUnknown, 0, 0, nil, nil
+	}
+	for isPublicKeyPush(script, offset) {
+		pubkeys = append(pubkeys, script[offset+1:offset+1+publicKeyLength])
+		offset += 1 + publicKeyLength
+	}
+	if isTruncatedPush(script, offset) {
+		return VerificationScriptUnknown, 0, 0, nil, fmt.Errorf("malformed verification script: unexpected end of script at %v", offset)
+	}
+	n, offset, err = readPushedInt(script, offset)
+	if err != nil {
+		return VerificationScriptUnknown, 0, 0, nil, nil
+	}
+	//the only thing left must be CHECKMULTISIG
+	if offset != len(script)-1 {
+		return VerificationScriptUnknown, 0, 0, nil, nil
+	}
+	if n != len(pubkeys) || m < 1 || m > n {
+		return VerificationScriptUnknown, 0, 0, nil, nil
+	}
+	return VerificationScriptMultiSig, m, n, pubkeys, nil
+}
@@ -0,0 +1,74 @@
+package smartcontract_test
+
+import (
+	"encoding/hex"
+	"log"
+	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
+)
+
+func TestClassifySingleSigVerificationScript(t *testing.T) {
+	script, _ := hex.DecodeString("21031a6c6fbbdf02ca351745fa86b9ba5a9452d785ac4f7fc2b7548ca2a46c4fcf4aac")
+	kind, m, n, pubkeys, err := smartcontract.ClassifyVerificationScript(script)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	if kind != smartcontract.VerificationScriptSingleSig || m != 1 || n != 1 || len(pubkeys) != 1 {
+		t.Fail()
+		return
+	}
+	if hex.EncodeToString(pubkeys[0]) != "031a6c6fbbdf02ca351745fa86b9ba5a9452d785ac4f7fc2b7548ca2a46c4fcf4a" {
+		t.Fail()
+	}
+}
+
+func TestClassifyMultiSigVerificationScript(t *testing.T) {
+	script, _ := hex.DecodeString("5221024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff02102e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a898652ae")
+	kind, m, n, pubkeys, err := smartcontract.ClassifyVerificationScript(script)
+	if err != nil {
+		log.Printf("%v", err)
+		t.Fail()
+		return
+	}
+	log.Printf("%v %v-of-%v", kind, m, n)
+	if kind != smartcontract.VerificationScriptMultiSig || m != 2 || n != 2 || len(pubkeys) != 2 {
+		t.Fail()
+		return
+	}
+	if hex.EncodeToString(pubkeys[0]) != "024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff0" {
+		t.Fail()
+	}
+}
+
+func TestClassifyUnknownVerificationScript(t *testing.T) {
+	//contract invocation script
+	script, _ := hex.DecodeString("51143acefb110cba488ae0d809f5837b0ac9c895405e52c10c6d696e74546f6b656e73546f67b17f078543788c588ce9e75544e325a050f8c1b7")
+	kind, _, _, _, err := smartcontract.ClassifyVerificationScript(script)
+	if kind != smartcontract.VerificationScriptUnknown || err != nil {
+		t.Fail()
+	}
+
+	//key count doesn't match the number of public keys
+	script, _ = hex.DecodeString("5221024da93f9a66981e499b36ce763e57fd89a47a052e86d40b42f81708c40fe9eff02102e77ff280db51ef3638009f11947c544ed094d4e5f2d96a9e654dc817bc3a898653ae")
+	kind, _, _, _, err = smartcontract.ClassifyVerificationScript(script)
+	if kind != smartcontract.VerificationScriptUnknown || err != nil {
+		t.Fail()
+	}
+}
+
+func TestClassifyMalformedVerificationScript(t *testing.T) {
+	//PUSHBYTES33 with only 2 bytes of the public key
+	script, _ := hex.DecodeString("21031aac")
+	kind, _, _, _, err := smartcontract.ClassifyVerificationScript(script)
+	if kind != smartcontract.VerificationScriptUnknown || err == nil {
+		t.Fail()
+	}
+
+	_, _, _, _, err = smartcontract.ClassifyVerificationScript([]byte{})
+	if err == nil {
+		t.Fail()
+	}
+}
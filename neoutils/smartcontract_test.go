@@ -165,3 +165,97 @@ func TestCallDeployFunction(t *testing.T) {
 	}
 	log.Printf("%x", tx)
 }
+
+func TestInvokeFunctionScriptAttributeOnlyWithoutInputs(t *testing.T) {
+	wallet, _ := neoutils.NewWallet()
+	sc := neoutils.UseSmartContract("ce575ae1bb6153330d20c560acb434dc5755241b")
+
+	//number of attributes is right after the length prefixed invocation script
+	attributesOf := func(raw []byte) []byte {
+		dataLength := int(raw[2])
+		return raw[3+dataLength:]
+	}
+
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1},
+				},
+			},
+		},
+	}
+	raw, err := sc.GenerateInvokeFunctionRawTransaction(*wallet, unspent, nil, "name", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//sender owns the input so no attribute is needed
+	if attributesOf(raw)[0] != 0x00 {
+		t.Fatalf("expected no attribute got %x", attributesOf(raw))
+	}
+
+	raw, err = sc.GenerateInvokeFunctionRawTransaction(*wallet, smartcontract.Unspent{}, nil, "name", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := attributesOf(raw)
+	sender := smartcontract.ParseNEOAddress(wallet.Address)
+	expected := fmt.Sprintf("0120%x", []byte(sender))
+	if fmt.Sprintf("%x", attributes[:22]) != expected {
+		t.Fatalf("expected %v got %x", expected, attributes[:22])
+	}
+}
+
+func TestInvokeFunctionWithAttachedGASAndNetworkFee(t *testing.T) {
+	wallet, _ := neoutils.NewWallet()
+	sc := neoutils.UseSmartContractWithNetworkFee("ce575ae1bb6153330d20c560acb434dc5755241b", 0.5)
+
+	unspent := smartcontract.Unspent{
+		Assets: map[smartcontract.NativeAsset]*smartcontract.Balance{
+			smartcontract.GAS: &smartcontract.Balance{
+				Amount: 6,
+				UTXOs: []smartcontract.UTXO{
+					{Index: 0, TXID: "1b640fc70e127a74ab6785afe155f089e08a153b2effc7a4bed8b6690cfc65fe", Value: 1},
+					{Index: 1, TXID: "307d756074d9ee11220ccebf003bedb99f9b1a54e194a25e6ea5df1a7b2de84b", Value: 5},
+				},
+			},
+		},
+	}
+	raw, err := sc.GenerateInvokeFunctionRawTransactionWithAmountToSend(*wallet, smartcontract.GAS, 1, unspent, nil, "mintTokens", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//the contract script hash is appended after the signed transaction
+	tx, err := smartcontract.DeserializeTransaction(raw[:len(raw)-20])
+	if err != nil {
+		t.Fatal(err)
+	}
+	//1 GAS alone is not enough to pay the attached amount and the fee
+	if len(tx.Inputs) != 1+2*34 || tx.Inputs[0] != 2 {
+		t.Fatalf("expected 2 inputs got %x", tx.Inputs)
+	}
+	//outputs = [output_count] + 60 bytes each
+	if len(tx.Outputs) != 1+2*60 || tx.Outputs[0] != 2 {
+		t.Fatalf("expected 2 outputs got %x", tx.Outputs)
+	}
+	scriptHash, _ := smartcontract.NewScriptHash("ce575ae1bb6153330d20c560acb434dc5755241b")
+	contract := smartcontract.NEOAddressFromScriptHash(scriptHash.ToBigEndian())
+	sender := smartcontract.ParseNEOAddress(wallet.Address)
+	expected := []smartcontract.TransactionOutput{
+		{Asset: smartcontract.GAS, Value: smartcontract.NewFixed8(1), Address: contract},
+		{Asset: smartcontract.GAS, Value: smartcontract.NewFixed8(4.5), Address: sender},
+	}
+	for i, output := range expected {
+		b := tx.Outputs[1+i*60 : 1+(i+1)*60]
+		value := smartcontract.Fixed8(binary.LittleEndian.Uint64(b[32:40]))
+		if value != output.Value || hex.EncodeToString(b[40:]) != hex.EncodeToString(output.Address) {
+			t.Fatalf("output %v expected %v to %v got %x", i, output.Value, output.Address.ToString(), b)
+		}
+	}
+
+	hash := tx.SigningHash()
+	if len(tx.Script) < 67 || neoutils.Verify(wallet.PublicKey, tx.Script[3:67], hash[:]) == false {
+		t.Fatalf("invalid signature")
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	nep9 "github.com/o3labs/NEP9-go/nep9"
 	"github.com/o3labs/neo-utils/neoutils/btckey"
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -20,6 +21,16 @@ func ReverseBytes(b []byte) []byte {
 	return b
 }
 
+// Reverse the byte order of a hex string
+// e.g. script hash or txid from big endian to little endian and vice versa
+func ReverseHexString(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(ReverseBytes(b)), nil
+}
+
 // Simple hex string to bytes
 func HexTobytes(hexstring string) (b []byte) {
 	b, _ = hex.DecodeString(hexstring)
@@ -38,10 +49,33 @@ func ScriptHashToNEOAddress(scriptHash string) string {
 	//script hash from rpc or anything is always in big endian
 	//to convert to a proper neo address
 	//we need to reverse it first
-	address := btckey.B58checkencodeNEO(0x17, ReverseBytes(b))
+	address := btckey.B58checkencodeNEO(smartcontract.AddressVersion, ReverseBytes(b))
 	return address
 }
 
+// Convert the 20 bytes of a Hash160 read off the VM stack to a NEO address e.g. a ByteArray returned by invokescript
+// The VM has the script hash in little endian which is already the order of the address so unlike ScriptHashToNEOAddress it's not reversed
+func StackBytesToNEOAddress(stackBytes []byte) (string, error) {
+	if len(stackBytes) != smartcontract.Uint160Length {
+		return "", fmt.Errorf("script hash must be %v bytes but got %v", smartcontract.Uint160Length, len(stackBytes))
+	}
+	return btckey.B58checkencodeNEO(smartcontract.AddressVersion, stackBytes), nil
+}
+
+// Convert a contract script hash in explorer form (big endian, 0x prefix is optional)
+// It returns the little endian ScriptHash used when invoking the contract and the address of the contract
+func ContractScriptHash(explorerScriptHash string) (smartcontract.ScriptHash, string, error) {
+	scriptHash, err := smartcontract.NewScriptHash(explorerScriptHash)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(scriptHash) != smartcontract.Uint160Length {
+		return nil, "", fmt.Errorf("script hash must be %v bytes but got %v", smartcontract.Uint160Length, len(scriptHash))
+	}
+	address := btckey.B58checkencodeNEO(smartcontract.AddressVersion, scriptHash)
+	return scriptHash, address, nil
+}
+
 // // Convert NEO address to script hash
 // func NEOAddressToScriptHash(neoAddress string) string {
 // 	v, b, _ := btckey.B58checkdecode(neoAddress)
@@ -55,7 +89,7 @@ func ScriptHashToNEOAddress(scriptHash string) string {
 // Convert NEO address to script hash
 func NEOAddressToScriptHashWithEndian(neoAddress string, endian binary.ByteOrder) string {
 	v, b, _ := btckey.B58checkdecode(neoAddress)
-	if v != 0x17 {
+	if v != smartcontract.AddressVersion {
 		return ""
 	}
 	if endian == binary.LittleEndian {
@@ -68,13 +102,13 @@ func NEOAddressToScriptHashWithEndian(neoAddress string, endian binary.ByteOrder
 
 // Validate NEO address
 func ValidateNEOAddress(address string) bool {
-	//NEO address version is 23
+	//NEO address version is 23 unless smartcontract.AddressVersion is changed
 	//https://github.com/neo-project/neo/blob/427a3cd08f61a33e98856e4b4312b8147708105a/neo/protocol.json#L4
 	ver, _, err := btckey.B58checkdecode(address)
 	if err != nil {
 		return false
 	}
-	if ver != 23 {
+	if ver != smartcontract.AddressVersion {
 		return false
 	}
 	return true
@@ -144,7 +178,7 @@ func PublicKeyToNEOAddress(publicKeyBytes []byte) string {
 
 	program_hash := pub_hash_2
 
-	address := btckey.B58checkencodeNEO(0x17, program_hash)
+	address := btckey.B58checkencodeNEO(smartcontract.AddressVersion, program_hash)
 	return address
 }
 
@@ -163,6 +197,6 @@ func VMCodeToNEOAddress(vmCode []byte) string {
 
 	program_hash := pub_hash_2
 
-	address := btckey.B58checkencodeNEO(0x17, program_hash)
+	address := btckey.B58checkencodeNEO(smartcontract.AddressVersion, program_hash)
 	return address
 }
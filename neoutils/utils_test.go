@@ -7,6 +7,8 @@ import (
 	"log"
 	"math"
 	"testing"
+
+	"github.com/o3labs/neo-utils/neoutils/smartcontract"
 )
 
 func TestScriptHashToNEOAddress(t *testing.T) {
@@ -23,6 +25,50 @@ func TestScriptHashToNEOAddress(t *testing.T) {
 	}
 }
 
+func TestStackBytesToNEOAddress(t *testing.T) {
+	//ByteArray value on the stack after invoking a contract that returns the Hash160 of AKibPRzkoZpHnPkF6qvuW2Q4hG9gKBwGpR
+	stackValue := "2b41aea9d405fef2e809e3c8085221ce944527a7"
+	address, err := StackBytesToNEOAddress(hex2bytes(stackValue))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "AKibPRzkoZpHnPkF6qvuW2Q4hG9gKBwGpR" {
+		t.Fatalf("unexpected address %v", address)
+	}
+	if address != ScriptHashToNEOAddress(fmt.Sprintf("%x", ReverseBytes(hex2bytes(stackValue)))) {
+		t.Fatal("expected the same address as the big endian script hash")
+	}
+
+	_, err = StackBytesToNEOAddress(hex2bytes(stackValue)[1:])
+	if err == nil {
+		t.Fatal("expected an error for 19 bytes")
+	}
+}
+
+func TestAddressHelpersFollowAddressVersion(t *testing.T) {
+	smartcontract.AddressVersion = smartcontract.NEO3AddressVersion
+	defer func() { smartcontract.AddressVersion = smartcontract.NEO2AddressVersion }()
+
+	bigEndian := "a7274594ce215208c8e309e8f2fe05d4a9ae412b"
+	address := ScriptHashToNEOAddress(bigEndian)
+	if address[0] != 'N' || ValidateNEOAddress(address) == false {
+		t.Fatalf("expected a NEO 3 address got %v", address)
+	}
+	if NEOAddressToScriptHashWithEndian(address, binary.BigEndian) != bigEndian {
+		t.Fatalf("expected %v got %v", bigEndian, NEOAddressToScriptHashWithEndian(address, binary.BigEndian))
+	}
+	if smartcontract.ParseNEOAddress(address) == nil {
+		t.Fatal("expected ParseNEOAddress to accept the same address")
+	}
+	publicKey, _ := hex.DecodeString("022c9652d3ad5cc065aa9147dc2ad022f80001e8ed233de20f352950d351d472b7")
+	if PublicKeyToNEOAddress(publicKey)[0] != 'N' || VMCodeToNEOAddress([]byte{0x51})[0] != 'N' {
+		t.Fatal("expected NEO 3 addresses")
+	}
+	if ValidateNEOAddress("AKibPRzkoZpHnPkF6qvuW2Q4hG9gKBwGpR") == true {
+		t.Fatal("expected a NEO 2 address to be rejected")
+	}
+}
+
 func TestSmartContractScripthashToAddress(t *testing.T) {
 	address := ScriptHashToNEOAddress("fb5f6ac2a3b8396f8eafa5ac5c8f28ffcd247fc4")
 	log.Printf("%v", address)
@@ -96,3 +142,44 @@ func TestPublicKeyToNEOAddress(t *testing.T) {
 	address := PublicKeyToNEOAddress(b)
 	log.Printf("%v", address)
 }
+
+func TestReverseHexString(t *testing.T) {
+	reversed, err := ReverseHexString("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reversed != "f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec" {
+		t.Fatalf("unexpected %v", reversed)
+	}
+
+	empty, err := ReverseHexString("")
+	if err != nil || empty != "" {
+		t.Fail()
+	}
+
+	_, err = ReverseHexString("abc")
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestContractScriptHash(t *testing.T) {
+	//RPX
+	for _, explorerForm := range []string{"0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"} {
+		scriptHash, address, err := ContractScriptHash(explorerForm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(scriptHash) != "f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec" {
+			t.Fatalf("expected little endian script hash got %x", []byte(scriptHash))
+		}
+		if address != "AeV59NyZtgj5AMQ7vY6yhr2MRvcfFeLWSb" {
+			t.Fatalf("unexpected address %v", address)
+		}
+	}
+
+	_, _, err := ContractScriptHash("0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1d")
+	if err == nil {
+		t.Fail()
+	}
+}